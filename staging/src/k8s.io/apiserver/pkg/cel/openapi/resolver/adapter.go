@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// legacyRefPrefix is the OpenAPI v2 definitions prefix.
+const legacyRefPrefix = "#/definitions/"
+
+// CanonicalizeRef normalizes a schema ref to its bare definition name, so
+// that refs produced by Swagger 2.0 ("#/definitions/...") and OpenAPI v3
+// ("#/components/schemas/...") toolchains both resolve against the same
+// lookup. URL-encoded characters are decoded and JSON-pointer escapes ("~1"
+// for "/", "~0" for "~") are unescaped. Refs already in bare-name form come
+// back unchanged.
+func CanonicalizeRef(ref string) string {
+	name := strings.TrimPrefix(ref, refPrefix)
+	name = strings.TrimPrefix(name, legacyRefPrefix)
+	if decoded, err := url.PathUnescape(name); err == nil {
+		name = decoded
+	}
+	name = strings.ReplaceAll(name, "~1", "/")
+	return strings.ReplaceAll(name, "~0", "~")
+}
+
+// SchemaOfFromResolver adapts a SchemaResolver into the schemaOf callback
+// consumed by PopulateRefs, so that a resolver can back ref expansion without
+// a hand-written closure. The callback parses each ref into a GVK, resolves
+// it, and reports false when the ref does not parse or the resolver returns
+// an error.
+//
+// The ref grammar is an optional "#/components/schemas/" or "#/definitions/"
+// prefix followed by the definition name in one of two forms:
+//
+//   - dotted: <group>.<version>.<kind>, where the last two dot-separated
+//     segments are the version and kind and everything before them is the
+//     group. The group may itself contain dots, and is empty for the core
+//     group (i.e. <version>.<kind>).
+//   - legacy slash: <group>/<version>/<kind>, or <version>/<kind> for the
+//     core group.
+func SchemaOfFromResolver(r SchemaResolver) func(ref string) (*spec.Schema, bool) {
+	return func(ref string) (*spec.Schema, bool) {
+		gvk, ok := parseRefGVK(ref)
+		if !ok {
+			return nil, false
+		}
+		s, err := r.ResolveSchema(gvk)
+		if err != nil {
+			return nil, false
+		}
+		return s, true
+	}
+}
+
+// parseRefGVK parses a definition ref into the GVK it names, per the grammar
+// documented on SchemaOfFromResolver.
+func parseRefGVK(ref string) (schema.GroupVersionKind, bool) {
+	name := strings.TrimPrefix(ref, refPrefix)
+	name = strings.TrimPrefix(name, legacyRefPrefix)
+	if strings.Contains(name, "/") {
+		parts := strings.Split(name, "/")
+		switch len(parts) {
+		case 2:
+			return schema.GroupVersionKind{Version: parts[0], Kind: parts[1]}, parts[0] != "" && parts[1] != ""
+		case 3:
+			return schema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]},
+				parts[0] != "" && parts[1] != "" && parts[2] != ""
+		default:
+			return schema.GroupVersionKind{}, false
+		}
+	}
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return schema.GroupVersionKind{}, false
+	}
+	version := parts[len(parts)-2]
+	kind := parts[len(parts)-1]
+	if version == "" || kind == "" {
+		return schema.GroupVersionKind{}, false
+	}
+	return schema.GroupVersionKind{
+		Group:   strings.Join(parts[:len(parts)-2], "."),
+		Version: version,
+		Kind:    kind,
+	}, true
+}
+
+// PopulateRefsForGVK resolves the root schema of gvk through the resolver and
+// expands any refs left in the result, using the resolver itself as the ref
+// source. It bridges GVK-based callers to the ref-string contract of
+// PopulateRefs without each of them rebuilding the adapter. ErrRootNotFound
+// is returned when the GVK is unknown to the resolver.
+func PopulateRefsForGVK(r SchemaResolver, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	root, err := r.ResolveSchema(gvk)
+	if err != nil {
+		if errors.Is(err, ErrSchemaNotFound) {
+			return nil, fmt.Errorf("cannot resolve root schema for %v: %w", gvk, ErrRootNotFound)
+		}
+		return nil, err
+	}
+	return PopulateRefsInSchema(SchemaOfFromResolver(r), root)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestSchemaOfFromResolver(t *testing.T) {
+	widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	objectSchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	schemaOf := SchemaOfFromResolver(mapResolver{widget: objectSchema, pod: objectSchema})
+
+	for _, tc := range []struct {
+		name string
+		ref  string
+		want bool
+	}{
+		{name: "dotted", ref: "example.com.v1.Widget", want: true},
+		{name: "dotted core group", ref: "v1.Pod", want: true},
+		{name: "dotted with prefix", ref: "#/components/schemas/example.com.v1.Widget", want: true},
+		{name: "legacy slash", ref: "#/definitions/example.com/v1/Widget", want: true},
+		{name: "legacy slash core group", ref: "#/definitions/v1/Pod", want: true},
+		{name: "unknown kind", ref: "example.com.v1.Gadget", want: false},
+		{name: "too few segments", ref: "Widget", want: false},
+		{name: "too many slash segments", ref: "a/b/c/d", want: false},
+		{name: "empty segment", ref: "example.com..Widget", want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, ok := schemaOf(tc.ref)
+			if ok != tc.want {
+				t.Fatalf("expected ok=%v for %q, got %v", tc.want, tc.ref, ok)
+			}
+			if ok && s == nil {
+				t.Errorf("expected a schema for %q", tc.ref)
+			}
+		})
+	}
+}
+
+func TestSchemaOfFromResolverWithPopulateRefs(t *testing.T) {
+	widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	r := mapResolver{widget: {SchemaProps: spec.SchemaProps{Type: []string{"object"}}}}
+	resolved, err := PopulateRefs(SchemaOfFromResolver(r), "example.com.v1.Widget")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resolved.Type) != 1 || resolved.Type[0] != "object" {
+		t.Errorf("expected the resolved root schema, got %v", resolved)
+	}
+}
+
+func TestCanonicalizeRef(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{name: "v2 definitions prefix", ref: "#/definitions/io.k8s.api.core.v1.Pod", want: "io.k8s.api.core.v1.Pod"},
+		{name: "v3 components prefix", ref: "#/components/schemas/io.k8s.api.core.v1.Pod", want: "io.k8s.api.core.v1.Pod"},
+		{name: "bare name", ref: "io.k8s.api.core.v1.Pod", want: "io.k8s.api.core.v1.Pod"},
+		{name: "pointer escape", ref: "#/definitions/io.k8s~1beta.Pod", want: "io.k8s/beta.Pod"},
+		{name: "tilde escape", ref: "#/definitions/a~0b", want: "a~b"},
+		{name: "url encoded", ref: "#/components/schemas/foo%20bar", want: "foo bar"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CanonicalizeRef(tc.ref); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveSchemaAcceptsBothRefDialects(t *testing.T) {
+	resolver := testDefinitionsResolver()
+	widget := resolver.defs["com.example.v1.Widget"]
+	widget.Schema.Properties["spec"] = spec.Schema{SchemaProps: spec.SchemaProps{
+		Ref: spec.MustCreateRef("#/components/schemas/com.example.v1.WidgetSpec"),
+	}}
+	resolver.defs["com.example.v1.Widget"] = widget
+
+	s, err := resolver.ResolveSchema(testGVK)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Properties["spec"].Properties) == 0 {
+		t.Errorf("expected the v3-prefixed ref to resolve, got %v", s.Properties["spec"])
+	}
+}
+
+func TestPopulateRefsForGVK(t *testing.T) {
+	r := testDefinitionsResolver()
+	resolved, err := PopulateRefsForGVK(r, testGVK)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	specProp, ok := resolved.Properties["spec"]
+	if !ok {
+		t.Fatal("expected a spec property")
+	}
+	if _, ok := specProp.Properties["replicas"]; !ok {
+		t.Errorf("expected the nested ref to be expanded, got %v", specProp)
+	}
+	if count := countRefs(resolved); count != 0 {
+		t.Errorf("expected no refs to remain, found %d", count)
+	}
+
+	unknown := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+	if _, err := PopulateRefsForGVK(r, unknown); !errors.Is(err, ErrRootNotFound) {
+		t.Errorf("expected ErrRootNotFound for an unknown GVK, got %v", err)
+	}
+}
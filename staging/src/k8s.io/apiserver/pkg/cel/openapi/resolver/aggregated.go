@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// NewKnownAggregatedResolver returns a SchemaResolver preloaded with schemas
+// for well-known aggregated APIs whose shapes are stable: the resource
+// metrics API (metrics.k8s.io) and the custom metrics API
+// (custom.metrics.k8s.io). Equivalent to
+// NewCatalogResolver(CatalogAggregatedMetrics); the same partial-coverage
+// caveats apply.
+func NewKnownAggregatedResolver() SchemaResolver {
+	return &catalogResolver{schemas: knownAggregatedSchemas()}
+}
+
+// knownAggregatedSchemas covers, besides apiVersion/kind/metadata(name,
+// namespace, labels): NodeMetrics timestamp, window, and usage; PodMetrics
+// timestamp, window, and containers[].name/usage; MetricValue metric.name,
+// describedObject, timestamp, windowSeconds, and value.
+func knownAggregatedSchemas() map[schema.GroupVersionKind]*spec.Schema {
+	stringType := *spec.StringProperty()
+	quantityMap := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		AdditionalProperties: &spec.SchemaOrBool{
+			Allows: true,
+			Schema: spec.StringProperty(),
+		},
+	}}
+	metadata := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name":      stringType,
+			"namespace": stringType,
+			"labels": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AdditionalProperties: &spec.SchemaOrBool{
+					Allows: true,
+					Schema: spec.StringProperty(),
+				},
+			}},
+		},
+	}}
+	typeMeta := map[string]spec.Schema{
+		"apiVersion": stringType,
+		"kind":       stringType,
+		"metadata":   metadata,
+	}
+
+	nodeMetrics := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"timestamp": {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "date-time"}},
+		"window":    stringType,
+		"usage":     quantityMap,
+	})
+	podMetrics := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"timestamp": {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "date-time"}},
+		"window":    stringType,
+		"containers": {SchemaProps: spec.SchemaProps{
+			Type: []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name":  stringType,
+					"usage": quantityMap,
+				},
+			}}},
+		}},
+	})
+	metricValue := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"metric": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"name": stringType,
+			},
+		}},
+		"describedObject": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"apiVersion": stringType,
+				"kind":       stringType,
+				"name":       stringType,
+				"namespace":  stringType,
+			},
+		}},
+		"timestamp":     {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "date-time"}},
+		"windowSeconds": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Format: "int64"}},
+		"value":         stringType,
+	})
+
+	return map[schema.GroupVersionKind]*spec.Schema{
+		{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}:        nodeMetrics,
+		{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}:         podMetrics,
+		{Group: "custom.metrics.k8s.io", Version: "v1beta2", Kind: "MetricValue"}: metricValue,
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestKnownAggregatedResolver(t *testing.T) {
+	r := NewKnownAggregatedResolver()
+	s, err := r.ResolveSchema(schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Type.Contains("object") {
+		t.Errorf("expected an object schema, got %v", s.Type)
+	}
+	for _, name := range []string{"apiVersion", "kind", "metadata", "containers", "timestamp"} {
+		if _, ok := s.Properties[name]; !ok {
+			t.Errorf("expected PodMetrics schema to declare %q", name)
+		}
+	}
+	containers := s.Properties["containers"]
+	if containers.Items == nil || containers.Items.Schema == nil {
+		t.Fatal("expected containers to be a typed array")
+	}
+	if _, ok := containers.Items.Schema.Properties["usage"]; !ok {
+		t.Error("expected containers items to declare usage")
+	}
+}
+
+func TestKnownAggregatedResolverUnknownGVK(t *testing.T) {
+	r := NewKnownAggregatedResolver()
+	gvk := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "ClusterMetrics"}
+	if _, err := r.ResolveSchema(gvk); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for unknown kind, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NameAliases returns an Options.ResolveAlias callback backed by a fixed map
+// of alternate name to indexed name. Refs absent from the map yield no alias.
+func NameAliases(aliases map[string]string) func(ref string) (string, error) {
+	return func(ref string) (string, error) {
+		return aliases[CanonicalizeRef(ref)], nil
+	}
+}
+
+// SuffixAliases returns an Options.ResolveAlias callback that matches a short
+// ref like "Address" against the dot-separated suffixes of the given
+// definition names, so that it resolves to "com.example.v1.Address" without
+// rewriting the document. A ref matching the suffix of exactly one name
+// yields that name; matching several is an error naming the candidates, and
+// matching none yields no alias. Because ResolveAlias only runs after
+// schemaOf has missed, an exactly-indexed name always wins over a suffix
+// match.
+func SuffixAliases(names []string) func(ref string) (string, error) {
+	return func(ref string) (string, error) {
+		short := CanonicalizeRef(ref)
+		var matches []string
+		for _, name := range names {
+			if strings.HasSuffix(name, "."+short) {
+				matches = append(matches, name)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return "", nil
+		case 1:
+			return matches[0], nil
+		default:
+			sort.Strings(matches)
+			return "", fmt.Errorf("short name %q is ambiguous: matches %s", short, strings.Join(matches, ", "))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func aliasTestSchemas() map[string]*spec.Schema {
+	return map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"address": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Address")}},
+			},
+		}},
+		"com.example.v1.Address": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"street": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		}},
+	}
+}
+
+func schemaOfMap(schemas map[string]*spec.Schema) func(ref string) (*spec.Schema, bool) {
+	return func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+}
+
+func TestResolveAliasMap(t *testing.T) {
+	schemas := aliasTestSchemas()
+	resolved, err := PopulateRefsWithOptions(schemaOfMap(schemas), "Root", Options{
+		ResolveAlias: NameAliases(map[string]string{"Address": "com.example.v1.Address"}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resolved.Properties["address"].Properties["street"]; !ok {
+		t.Errorf("expected the short-name ref to resolve through the alias, got %v", resolved)
+	}
+}
+
+func TestResolveAliasUniqueSuffix(t *testing.T) {
+	schemas := aliasTestSchemas()
+	names := []string{"Root", "com.example.v1.Address"}
+	resolved, err := PopulateRefsWithOptions(schemaOfMap(schemas), "Root", Options{
+		ResolveAlias: SuffixAliases(names),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resolved.Properties["address"].Properties["street"]; !ok {
+		t.Errorf("expected the unique suffix match to resolve, got %v", resolved)
+	}
+}
+
+func TestResolveAliasAmbiguousSuffix(t *testing.T) {
+	schemas := aliasTestSchemas()
+	schemas["com.example.v2.Address"] = schemas["com.example.v1.Address"]
+	names := []string{"Root", "com.example.v1.Address", "com.example.v2.Address"}
+	_, err := PopulateRefsWithOptions(schemaOfMap(schemas), "Root", Options{
+		ResolveAlias: SuffixAliases(names),
+	})
+	if err == nil {
+		t.Fatal("expected an error for an ambiguous short name")
+	}
+	if !strings.Contains(err.Error(), "com.example.v1.Address") || !strings.Contains(err.Error(), "com.example.v2.Address") {
+		t.Errorf("expected the error to name both candidates, got %v", err)
+	}
+}
+
+func TestResolveAliasNoMatch(t *testing.T) {
+	schemas := aliasTestSchemas()
+	var unresolvedErr *UnresolvedRefError
+	_, err := PopulateRefsWithOptions(schemaOfMap(schemas), "Root", Options{
+		ResolveAlias: SuffixAliases([]string{"Root", "com.example.v1.Widget"}),
+	})
+	if !errors.As(err, &unresolvedErr) {
+		t.Fatalf("expected an UnresolvedRefError for a short name with no match, got %v", err)
+	}
+	if unresolvedErr.Ref != "Address" {
+		t.Errorf("expected the original ref in the error, got %q", unresolvedErr.Ref)
+	}
+}
+
+func TestResolveAliasExactMatchWins(t *testing.T) {
+	schemas := aliasTestSchemas()
+	// an exactly-indexed short name resolves directly, not via the suffix
+	schemas["Address"] = &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"exact": {SchemaProps: spec.SchemaProps{Type: []string{"boolean"}}},
+		},
+	}}
+	resolved, err := PopulateRefsWithOptions(schemaOfMap(schemas), "Root", Options{
+		ResolveAlias: SuffixAliases([]string{"Root", "Address", "com.example.v1.Address"}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resolved.Properties["address"].Properties["exact"]; !ok {
+		t.Errorf("expected the exact match to win over the suffix match, got %v", resolved)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// SchemaAllocator supplies the schema copies created during resolution so
+// that repeated resolutions can reuse memory, e.g. in an admission hot path.
+// Schemas passed to Free are always resolver-created copies; caller-provided
+// schemas are never recycled.
+type SchemaAllocator interface {
+	// Allocate returns a schema for the resolver to fill in.
+	Allocate() *spec.Schema
+	// Free returns a resolver-created schema that ended up unused.
+	Free(*spec.Schema)
+}
+
+// NewPooledSchemaAllocator returns a SchemaAllocator backed by a sync.Pool.
+func NewPooledSchemaAllocator() SchemaAllocator {
+	return &pooledSchemaAllocator{
+		pool: sync.Pool{New: func() any { return new(spec.Schema) }},
+	}
+}
+
+type pooledSchemaAllocator struct {
+	pool sync.Pool
+}
+
+func (a *pooledSchemaAllocator) Allocate() *spec.Schema {
+	return a.pool.Get().(*spec.Schema)
+}
+
+func (a *pooledSchemaAllocator) Free(s *spec.Schema) {
+	*s = spec.Schema{}
+	a.pool.Put(s)
+}
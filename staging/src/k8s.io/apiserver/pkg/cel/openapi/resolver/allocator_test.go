@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func benchmarkSchemas() map[string]*spec.Schema {
+	return map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec":   {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+					"status": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+				},
+			},
+		},
+		"#/definitions/Spec": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					"items": {SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+							Ref: spec.MustCreateRef("#/definitions/Item"),
+						}}},
+					}},
+				},
+			},
+		},
+		"#/definitions/Item": {
+			SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+		},
+	}
+}
+
+func TestPopulateRefsWithAllocator(t *testing.T) {
+	schemas := benchmarkSchemas()
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	plain, err := PopulateRefs(schemaOf, "#/definitions/Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pooled, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{Allocator: NewPooledSchemaAllocator()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.Properties["spec"].Properties["replicas"].Type[0] != pooled.Properties["spec"].Properties["replicas"].Type[0] {
+		t.Error("pooled resolution produced a different schema than non-pooled resolution")
+	}
+	// the caller's schemas must never be mutated by a pooled resolution
+	rootSpec := schemas["#/definitions/Root"].Properties["spec"]
+	if got := rootSpec.Ref.String(); got != "#/definitions/Spec" {
+		t.Errorf("caller-provided schema was mutated, ref is now %q", got)
+	}
+}
+
+func benchmarkPopulateRefs(b *testing.B, opts Options) {
+	schemas := benchmarkSchemas()
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", opts); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkPopulateRefs(b *testing.B) {
+	benchmarkPopulateRefs(b, Options{})
+}
+
+func BenchmarkPopulateRefsPooled(b *testing.B) {
+	benchmarkPopulateRefs(b, Options{Allocator: NewPooledSchemaAllocator()})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// APIServiceDocumentFetcher fetches the OpenAPI v3 document served by the
+// service backing an APIService. The APIService is identified by its name,
+// which follows the "<version>.<group>" convention.
+type APIServiceDocumentFetcher interface {
+	FetchDocument(apiServiceName string) ([]byte, error)
+}
+
+// APIServiceResolver resolves schemas of APIService-backed extension APIs on
+// demand: the backing service's OpenAPI document is fetched lazily on the
+// first resolution for its group version and cached per APIService. This
+// lets CEL policies target extension APIs without preloading their schemas.
+type APIServiceResolver struct {
+	fetcher APIServiceDocumentFetcher
+
+	mu   sync.Mutex
+	docs map[string]*schemaResponse
+}
+
+var _ SchemaResolver = (*APIServiceResolver)(nil)
+
+// NewAPIServiceResolver returns an APIServiceResolver using the given
+// fetcher.
+func NewAPIServiceResolver(fetcher APIServiceDocumentFetcher) *APIServiceResolver {
+	return &APIServiceResolver{fetcher: fetcher, docs: map[string]*schemaResponse{}}
+}
+
+func (r *APIServiceResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	name := apiServiceName(gvk.GroupVersion())
+	resp, err := r.document(name)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := resolveRef(resp, gvk)
+	if err != nil {
+		return nil, err
+	}
+	s, err := PopulateRefs(func(ref string) (*spec.Schema, bool) {
+		s, ok := resp.Components.Schemas[CanonicalizeRef(ref)]
+		return s, ok
+	}, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// document returns the parsed OpenAPI document for the named APIService,
+// fetching it on first use.
+func (r *APIServiceResolver) document(name string) (*schemaResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if resp, ok := r.docs[name]; ok {
+		return resp, nil
+	}
+	b, err := r.fetcher.FetchDocument(name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch OpenAPI document for APIService %q: %w", name, err)
+	}
+	resp := new(schemaResponse)
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	r.docs[name] = resp
+	return resp, nil
+}
+
+// apiServiceName derives the APIService object name for a group version.
+func apiServiceName(gv schema.GroupVersion) string {
+	return gv.Version + "." + gv.Group
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeDocumentFetcher serves fixed OpenAPI documents keyed by APIService
+// name and counts fetches.
+type fakeDocumentFetcher struct {
+	docs    map[string][]byte
+	fetches int
+}
+
+func (f *fakeDocumentFetcher) FetchDocument(apiServiceName string) ([]byte, error) {
+	f.fetches++
+	doc, ok := f.docs[apiServiceName]
+	if !ok {
+		return nil, fmt.Errorf("no such APIService %q", apiServiceName)
+	}
+	return doc, nil
+}
+
+const extensionAPIDocument = `{
+  "components": {
+    "schemas": {
+      "io.example.v1alpha1.Report": {
+        "type": "object",
+        "properties": {
+          "spec": {"$ref": "#/components/schemas/io.example.v1alpha1.ReportSpec"}
+        },
+        "x-kubernetes-group-version-kind": [
+          {"group": "reports.example.io", "version": "v1alpha1", "kind": "Report"}
+        ]
+      },
+      "io.example.v1alpha1.ReportSpec": {
+        "type": "object",
+        "properties": {
+          "severity": {"type": "string"}
+        }
+      }
+    }
+  }
+}`
+
+func TestAPIServiceResolver(t *testing.T) {
+	fetcher := &fakeDocumentFetcher{docs: map[string][]byte{
+		"v1alpha1.reports.example.io": []byte(extensionAPIDocument),
+	}}
+	r := NewAPIServiceResolver(fetcher)
+	gvk := schema.GroupVersionKind{Group: "reports.example.io", Version: "v1alpha1", Kind: "Report"}
+
+	s, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Properties["spec"].Properties["severity"].Type.Contains("string") {
+		t.Error("expected spec.severity to be resolved from the backend document")
+	}
+
+	// the document is cached per APIService
+	if _, err := r.ResolveSchema(gvk); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetcher.fetches != 1 {
+		t.Errorf("expected a single document fetch, got %d", fetcher.fetches)
+	}
+
+	// kinds absent from the document report not-found
+	missing := schema.GroupVersionKind{Group: "reports.example.io", Version: "v1alpha1", Kind: "Summary"}
+	if _, err := r.ResolveSchema(missing); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for a kind the backend does not serve, got %v", err)
+	}
+}
+
+func TestAPIServiceResolverFetchFailure(t *testing.T) {
+	r := NewAPIServiceResolver(&fakeDocumentFetcher{})
+	gvk := schema.GroupVersionKind{Group: "reports.example.io", Version: "v1alpha1", Kind: "Report"}
+	if _, err := r.ResolveSchema(gvk); err == nil {
+		t.Error("expected error when the APIService document cannot be fetched")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/utils/clock"
+)
+
+// BatchSchemaResolver resolves several GVKs with one combined fetch.
+// Delegates that support it allow the BatchingResolver to coalesce bursts of
+// resolutions into fewer underlying requests.
+type BatchSchemaResolver interface {
+	// ResolveSchemas resolves all given GVKs at once. GVKs that cannot be
+	// found are simply absent from the result map.
+	ResolveSchemas(gvks []schema.GroupVersionKind) (map[schema.GroupVersionKind]*spec.Schema, error)
+}
+
+// BatchingResolver debounces ResolveSchema calls: requests arriving within
+// the configured window are collected and issued as one combined fetch when
+// the delegate implements BatchSchemaResolver. Delegates without batch
+// support are called through directly.
+type BatchingResolver struct {
+	delegate SchemaResolver
+	window   time.Duration
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	pending   map[schema.GroupVersionKind][]chan batchResult
+	scheduled bool
+}
+
+type batchResult struct {
+	schema *spec.Schema
+	err    error
+}
+
+var _ SchemaResolver = (*BatchingResolver)(nil)
+
+// NewBatchingResolver returns a BatchingResolver that coalesces requests
+// arriving within the given window.
+func NewBatchingResolver(delegate SchemaResolver, window time.Duration) *BatchingResolver {
+	return &BatchingResolver{
+		delegate: delegate,
+		window:   window,
+		clock:    clock.RealClock{},
+		pending:  map[schema.GroupVersionKind][]chan batchResult{},
+	}
+}
+
+// ResolveSchema resolves the schema for gvk, possibly as part of a combined
+// fetch with other requests arriving within the batching window.
+func (r *BatchingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	batcher, ok := r.delegate.(BatchSchemaResolver)
+	if !ok {
+		return r.delegate.ResolveSchema(gvk)
+	}
+	ch := make(chan batchResult, 1)
+	r.mu.Lock()
+	r.pending[gvk] = append(r.pending[gvk], ch)
+	if !r.scheduled {
+		r.scheduled = true
+		timer := r.clock.NewTimer(r.window)
+		go func() {
+			<-timer.C()
+			r.flush(batcher)
+		}()
+	}
+	r.mu.Unlock()
+	result := <-ch
+	return result.schema, result.err
+}
+
+// flush issues the combined fetch for all pending requests and distributes
+// the results.
+func (r *BatchingResolver) flush(batcher BatchSchemaResolver) {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = map[schema.GroupVersionKind][]chan batchResult{}
+	r.scheduled = false
+	r.mu.Unlock()
+
+	gvks := make([]schema.GroupVersionKind, 0, len(pending))
+	for gvk := range pending {
+		gvks = append(gvks, gvk)
+	}
+	schemas, err := batcher.ResolveSchemas(gvks)
+	for gvk, waiters := range pending {
+		result := batchResult{err: err}
+		if err == nil {
+			s, ok := schemas[gvk]
+			if ok {
+				result.schema = s
+			} else {
+				result.err = fmt.Errorf("cannot resolve %v: %w", gvk, ErrSchemaNotFound)
+			}
+		}
+		for _, ch := range waiters {
+			ch <- result
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// batchingDelegate serves a fixed schema set and records batch fetches.
+type batchingDelegate struct {
+	schemas map[schema.GroupVersionKind]*spec.Schema
+
+	mu         sync.Mutex
+	batches    int
+	batchSizes []int
+}
+
+func (d *batchingDelegate) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, ok := d.schemas[gvk]
+	if !ok {
+		return nil, ErrSchemaNotFound
+	}
+	return s, nil
+}
+
+func (d *batchingDelegate) ResolveSchemas(gvks []schema.GroupVersionKind) (map[schema.GroupVersionKind]*spec.Schema, error) {
+	d.mu.Lock()
+	d.batches++
+	d.batchSizes = append(d.batchSizes, len(gvks))
+	d.mu.Unlock()
+	result := make(map[schema.GroupVersionKind]*spec.Schema, len(gvks))
+	for _, gvk := range gvks {
+		if s, ok := d.schemas[gvk]; ok {
+			result[gvk] = s
+		}
+	}
+	return result, nil
+}
+
+func TestBatchingResolverCoalescesWindow(t *testing.T) {
+	objectSchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	foo := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	bar := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Bar"}
+	missing := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Missing"}
+	delegate := &batchingDelegate{schemas: map[schema.GroupVersionKind]*spec.Schema{
+		foo: objectSchema,
+		bar: objectSchema,
+	}}
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	r := NewBatchingResolver(delegate, 50*time.Millisecond)
+	r.clock = fakeClock
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i, gvk := range []schema.GroupVersionKind{foo, bar, missing} {
+		wg.Add(1)
+		go func(i int, gvk schema.GroupVersionKind) {
+			defer wg.Done()
+			_, errs[i] = r.ResolveSchema(gvk)
+		}(i, gvk)
+	}
+
+	// wait until the flush timer is armed and all requests are pending
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		pending := len(r.pending)
+		r.mu.Unlock()
+		if pending == 3 && fakeClock.HasWaiters() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("requests did not queue within the window")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	fakeClock.Step(50 * time.Millisecond)
+	wg.Wait()
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("unexpected errors: %v, %v", errs[0], errs[1])
+	}
+	if !errors.Is(errs[2], ErrSchemaNotFound) {
+		t.Errorf("expected not-found for the missing GVK, got %v", errs[2])
+	}
+	if delegate.batches != 1 {
+		t.Errorf("expected one combined fetch, got %d", delegate.batches)
+	}
+	if len(delegate.batchSizes) == 1 && delegate.batchSizes[0] != 3 {
+		t.Errorf("expected the combined fetch to carry 3 GVKs, got %d", delegate.batchSizes[0])
+	}
+}
+
+func TestBatchingResolverPassThroughWithoutBatchSupport(t *testing.T) {
+	objectSchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	foo := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	r := NewBatchingResolver(mapResolver{foo: objectSchema}, 50*time.Millisecond)
+	// no fake clock needed: the call must not wait for any window
+	if _, err := r.ResolveSchema(foo); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// extBudgetOrigin records, on a node substituted during a budgeted pass, the
+// ref the node came from. A later resume reads it to rebuild the ancestry for
+// cycle detection; the final resume strips the markers again so the completed
+// result matches an unbounded PopulateRefs.
+const extBudgetOrigin = "x-populate-refs-origin"
+
+// PopulateRefsBudgeted expands the schema referred to by rootRef like
+// PopulateRefs, but visits at most roughly budget schema nodes. Refs reached
+// after the budget is spent are left collapsed, so the returned schema is
+// internally consistent: every frontier node keeps its $ref and can be listed
+// with CollapsedRefs. more reports whether any ref was left collapsed; pass
+// the result to ResumePopulateRefs to continue. Cyclic refs collapse to the
+// same empty-object placeholder PopulateRefs produces, so repeated resumption
+// always terminates. While more is true the result carries internal
+// provenance markers under extBudgetOrigin; they are removed once the
+// expansion completes. A budget of zero or less means unlimited, matching
+// PopulateRefs.
+func PopulateRefsBudgeted(schemaOf func(ref string) (*spec.Schema, bool), rootRef string, budget int) (*spec.Schema, bool, error) {
+	rootSchema, ok := schemaOf(rootRef)
+	if !ok {
+		return nil, false, fmt.Errorf("internal error: cannot resolve Ref for root schema %q: %w", rootRef, ErrRootNotFound)
+	}
+	return populateBudgeted(schemaOf, rootSchema, rootRef, budget)
+}
+
+// ResumePopulateRefs continues a budgeted expansion: it walks the partially
+// expanded schema and expands its remaining collapsed refs, again spending at
+// most roughly budget nodes. The partial schema is not mutated. Resuming
+// until more is false yields the same result as a single unbounded
+// PopulateRefs, including for cyclic schemas: the provenance markers carried
+// by the partial schema let a resume treat already expanded ancestors as
+// visited, so back-edges collapse to the usual placeholder instead of
+// unrolling one level per call.
+func ResumePopulateRefs(schemaOf func(ref string) (*spec.Schema, bool), partial *spec.Schema, budget int) (*spec.Schema, bool, error) {
+	return populateBudgeted(schemaOf, partial, "", budget)
+}
+
+func populateBudgeted(schemaOf func(ref string) (*spec.Schema, bool), root *spec.Schema, rootRef string, budget int) (*spec.Schema, bool, error) {
+	p := &populator{schemaOf: schemaOf, visited: sets.New[string](), origin: rootRef, refChain: []string{rootRef}, budget: budget}
+	if rootRef != "" {
+		p.visited.Insert(rootRef)
+	}
+	result, err := p.populateRefs(root, "")
+	if err != nil {
+		return nil, false, err
+	}
+	if len(p.errs) > 0 {
+		return nil, false, utilerrors.NewAggregate(p.errs)
+	}
+	if p.unexpanded == 0 {
+		result = stripBudgetMarkers(result)
+	}
+	return result, p.unexpanded > 0, nil
+}
+
+// stripBudgetMarkers removes the extBudgetOrigin markers left by earlier
+// budgeted passes, copying only the nodes it changes so the caller's partial
+// schema is never mutated.
+func stripBudgetMarkers(s *spec.Schema) *spec.Schema {
+	if s == nil {
+		return nil
+	}
+	result := s
+	changed := false
+	mutate := func() *spec.Schema {
+		if !changed {
+			copied := *result
+			result = &copied
+			changed = true
+		}
+		return result
+	}
+	if _, ok := s.Extensions[extBudgetOrigin]; ok {
+		exts := make(spec.Extensions, len(s.Extensions)-1)
+		for k, v := range s.Extensions {
+			if k != extBudgetOrigin {
+				exts[k] = v
+			}
+		}
+		if len(exts) == 0 {
+			exts = nil
+		}
+		mutate().Extensions = exts
+	}
+	stripMap := func(m map[string]spec.Schema) (map[string]spec.Schema, bool) {
+		var out map[string]spec.Schema
+		for name, node := range m {
+			stripped := stripBudgetMarkers(&node)
+			if stripped == &node {
+				continue
+			}
+			if out == nil {
+				out = make(map[string]spec.Schema, len(m))
+				for k, v := range m {
+					out[k] = v
+				}
+			}
+			out[name] = *stripped
+		}
+		return out, out != nil
+	}
+	if props, ok := stripMap(s.Properties); ok {
+		mutate().Properties = props
+	}
+	if props, ok := stripMap(s.PatternProperties); ok {
+		mutate().PatternProperties = props
+	}
+	depsCopied := false
+	for name, dep := range s.Dependencies {
+		if dep.Schema == nil {
+			continue
+		}
+		stripped := stripBudgetMarkers(dep.Schema)
+		if stripped == dep.Schema {
+			continue
+		}
+		r := mutate()
+		if !depsCopied {
+			deps := make(spec.Dependencies, len(s.Dependencies))
+			for k, v := range s.Dependencies {
+				deps[k] = v
+			}
+			r.Dependencies = deps
+			depsCopied = true
+		}
+		dep.Schema = stripped
+		r.Dependencies[name] = dep
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if stripped := stripBudgetMarkers(s.AdditionalProperties.Schema); stripped != s.AdditionalProperties.Schema {
+			addProps := *s.AdditionalProperties
+			addProps.Schema = stripped
+			mutate().AdditionalProperties = &addProps
+		}
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		if stripped := stripBudgetMarkers(s.Items.Schema); stripped != s.Items.Schema {
+			items := *s.Items
+			items.Schema = stripped
+			mutate().Items = &items
+		}
+	}
+	if s.Items != nil && len(s.Items.Schemas) > 0 {
+		if stripped, ok := stripSlice(s.Items.Schemas); ok {
+			items := *s.Items
+			items.Schemas = stripped
+			mutate().Items = &items
+		}
+	}
+	if stripped, ok := stripSlice(s.AllOf); ok {
+		mutate().AllOf = stripped
+	}
+	if stripped, ok := stripSlice(s.AnyOf); ok {
+		mutate().AnyOf = stripped
+	}
+	if stripped, ok := stripSlice(s.OneOf); ok {
+		mutate().OneOf = stripped
+	}
+	if s.Not != nil {
+		if stripped := stripBudgetMarkers(s.Not); stripped != s.Not {
+			mutate().Not = stripped
+		}
+	}
+	return result
+}
+
+// stripSlice applies stripBudgetMarkers to each element, returning a fresh
+// slice and true only when an element changed.
+func stripSlice(schemas []spec.Schema) ([]spec.Schema, bool) {
+	var out []spec.Schema
+	for i := range schemas {
+		node := schemas[i]
+		stripped := stripBudgetMarkers(&node)
+		if stripped == &node {
+			continue
+		}
+		if out == nil {
+			out = make([]spec.Schema, len(schemas))
+			copy(out, schemas)
+		}
+		out[i] = *stripped
+	}
+	return out, out != nil
+}
+
+// CollapsedRefs returns the sorted refs still present in a partially expanded
+// schema, i.e. the frontier a budgeted expansion stopped at.
+func CollapsedRefs(s *spec.Schema) []string {
+	return directRefs(s)
+}
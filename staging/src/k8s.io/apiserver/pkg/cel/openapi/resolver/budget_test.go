@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestPopulateRefsBudgetedResumesToCompletion(t *testing.T) {
+	schemas := wideTestSchemas(16)
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	want, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	partial, more, err := PopulateRefsBudgeted(schemaOf, "Root", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !more {
+		t.Fatal("expected the small budget to leave refs collapsed")
+	}
+	if len(CollapsedRefs(partial)) == 0 {
+		t.Fatal("expected a non-empty frontier")
+	}
+	for rounds := 0; more; rounds++ {
+		if rounds > 100 {
+			t.Fatal("resumption did not terminate")
+		}
+		partial, more, err = ResumePopulateRefs(schemaOf, partial, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !reflect.DeepEqual(want, partial) {
+		t.Error("expected resuming to completion to match the unbounded expansion")
+	}
+	if refs := CollapsedRefs(partial); len(refs) != 0 {
+		t.Errorf("expected no collapsed refs after completion, got %v", refs)
+	}
+	// originals are untouched
+	field := schemas["Root"].Properties["field000"]
+	if field.Ref.GetURL() == nil {
+		t.Error("original property ref was mutated")
+	}
+}
+
+func TestPopulateRefsBudgetedResumesThroughCycles(t *testing.T) {
+	refTo := func(ref string) spec.Schema {
+		return spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef(ref)}}
+	}
+	for _, tc := range []struct {
+		name     string
+		root     string
+		wantMore bool
+		schemas  map[string]*spec.Schema
+	}{
+		{
+			name: "direct cycle",
+			root: "A",
+			schemas: map[string]*spec.Schema{
+				"A": {SchemaProps: spec.SchemaProps{
+					Type:       []string{"object"},
+					Properties: map[string]spec.Schema{"b": refTo("B")},
+				}},
+				"B": {SchemaProps: spec.SchemaProps{
+					Type:       []string{"object"},
+					Properties: map[string]spec.Schema{"a": refTo("A")},
+				}},
+			},
+		},
+		{
+			// the cycle is only reached by a resume, which must treat the
+			// already expanded A as visited to terminate.
+			name:     "cycle behind a collapsed frontier",
+			root:     "R",
+			wantMore: true,
+			schemas: map[string]*spec.Schema{
+				"R": {SchemaProps: spec.SchemaProps{
+					Type:       []string{"object"},
+					Properties: map[string]spec.Schema{"a": refTo("A")},
+				}},
+				"A": {SchemaProps: spec.SchemaProps{
+					Type:       []string{"object"},
+					Properties: map[string]spec.Schema{"b": refTo("B")},
+				}},
+				"B": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"a":    refTo("A"),
+						"leaf": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					},
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaOf := func(ref string) (*spec.Schema, bool) {
+				s, ok := tc.schemas[ref]
+				return s, ok
+			}
+			want, err := PopulateRefs(schemaOf, tc.root)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			partial, more, err := PopulateRefsBudgeted(schemaOf, tc.root, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantMore && !more {
+				t.Fatal("expected the first pass to leave a collapsed frontier")
+			}
+			for rounds := 0; more; rounds++ {
+				if rounds > 10 {
+					t.Fatal("resumption did not terminate on the cyclic schema")
+				}
+				partial, more, err = ResumePopulateRefs(schemaOf, partial, 1)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if !reflect.DeepEqual(want, partial) {
+				t.Errorf("expected resuming to completion to match the unbounded expansion\nwant: %+v\ngot:  %+v", want, partial)
+			}
+		})
+	}
+}
+
+func TestPopulateRefsBudgetedMissingRoot(t *testing.T) {
+	schemaOf := func(ref string) (*spec.Schema, bool) { return nil, false }
+	if _, _, err := PopulateRefsBudgeted(schemaOf, "Missing", 5); !errors.Is(err, ErrRootNotFound) {
+		t.Errorf("expected ErrRootNotFound, got %v", err)
+	}
+}
+
+func TestPopulateRefsBudgetedUnlimited(t *testing.T) {
+	schemas := wideTestSchemas(4)
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	want, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, more, err := PopulateRefsBudgeted(schemaOf, "Root", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if more {
+		t.Error("expected no collapsed refs with an unlimited budget")
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Error("expected the unlimited budget to match PopulateRefs")
+	}
+}
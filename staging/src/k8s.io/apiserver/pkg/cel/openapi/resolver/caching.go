@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const (
+	defaultCacheEntries = 1024
+	defaultCacheTTL     = 10 * time.Minute
+)
+
+// CacheOptions configures NewCachingResolver.
+type CacheOptions struct {
+	// MaxEntries bounds how many GVKs the cache holds; the least recently
+	// used entries are evicted beyond it. Defaults to 1024.
+	MaxEntries int
+	// TTL is how long a successful resolution is served from cache.
+	// Defaults to 10 minutes.
+	TTL time.Duration
+	// NegativeTTL is how long a not-found result is served from cache, kept
+	// shorter than TTL so a newly installed type becomes resolvable without
+	// waiting out a full entry. Defaults to TTL / 10.
+	NegativeTTL time.Duration
+	// Clock drives entry expiry; it is injectable for tests and defaults to
+	// the real clock.
+	Clock utilcache.Clock
+}
+
+// CachingResolver decorates a SchemaResolver with a bounded TTL cache keyed
+// by GVK. Both successful resolutions and not-found results are cached;
+// other errors, such as transport failures, pass through uncached. It is
+// safe for concurrent use.
+type CachingResolver struct {
+	delegate    SchemaResolver
+	cache       *utilcache.LRUExpireCache
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// cacheEntry is a cached resolution outcome: a schema or a not-found error.
+type cacheEntry struct {
+	schema *spec.Schema
+	err    error
+}
+
+// NewCachingResolver returns a CachingResolver wrapping the given resolver.
+func NewCachingResolver(delegate SchemaResolver, opts CacheOptions) *CachingResolver {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = defaultCacheEntries
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultCacheTTL
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = opts.TTL / 10
+	}
+	var cache *utilcache.LRUExpireCache
+	if opts.Clock != nil {
+		cache = utilcache.NewLRUExpireCacheWithClock(opts.MaxEntries, opts.Clock)
+	} else {
+		cache = utilcache.NewLRUExpireCache(opts.MaxEntries)
+	}
+	return &CachingResolver{
+		delegate:    delegate,
+		cache:       cache,
+		ttl:         opts.TTL,
+		negativeTTL: opts.NegativeTTL,
+	}
+}
+
+func (r *CachingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	if v, ok := r.cache.Get(gvk); ok {
+		entry := v.(cacheEntry)
+		return entry.schema, entry.err
+	}
+	s, err := r.delegate.ResolveSchema(gvk)
+	switch {
+	case err == nil:
+		r.cache.Add(gvk, cacheEntry{schema: s}, r.ttl)
+	case errors.Is(err, ErrSchemaNotFound):
+		r.cache.Add(gvk, cacheEntry{err: err}, r.negativeTTL)
+	}
+	return s, err
+}
+
+// Purge drops every cached entry, forcing subsequent resolutions through the
+// delegate, e.g. after the backing OpenAPI document changed.
+func (r *CachingResolver) Purge() {
+	r.cache.RemoveAll(func(any) bool { return true })
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// stepClock is a manually advanced Clock for cache expiry tests.
+type stepClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *stepClock) Step(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func cachingTestGVK(i int) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: fmt.Sprintf("Kind%03d", i)}
+}
+
+func TestCachingResolverMemoizes(t *testing.T) {
+	counting := &lazyCountingResolver{delegate: mapResolver{
+		cachingTestGVK(0): {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}}
+	r := NewCachingResolver(counting, CacheOptions{})
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.ResolveSchema(cachingTestGVK(0)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if counting.calls != 1 {
+		t.Errorf("expected one delegate call, got %d", counting.calls)
+	}
+
+	// not-found results are cached too
+	for i := 0; i < 3; i++ {
+		if _, err := r.ResolveSchema(cachingTestGVK(1)); !errors.Is(err, ErrSchemaNotFound) {
+			t.Fatalf("expected ErrSchemaNotFound, got %v", err)
+		}
+	}
+	if counting.calls != 2 {
+		t.Errorf("expected one delegate call for the miss, got %d", counting.calls-1)
+	}
+
+	r.Purge()
+	if _, err := r.ResolveSchema(cachingTestGVK(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counting.calls != 3 {
+		t.Errorf("expected Purge to force a fresh delegate call, got %d calls", counting.calls)
+	}
+}
+
+func TestCachingResolverEviction(t *testing.T) {
+	defs := mapResolver{}
+	for i := 0; i < 4; i++ {
+		defs[cachingTestGVK(i)] = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	}
+	counting := &lazyCountingResolver{delegate: defs}
+	r := NewCachingResolver(counting, CacheOptions{MaxEntries: 2})
+
+	// fill past capacity, then revisit the first entry
+	for i := 0; i < 4; i++ {
+		if _, err := r.ResolveSchema(cachingTestGVK(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	calls := counting.calls
+	if _, err := r.ResolveSchema(cachingTestGVK(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counting.calls != calls+1 {
+		t.Error("expected the evicted entry to be resolved through the delegate again")
+	}
+}
+
+func TestCachingResolverTTL(t *testing.T) {
+	clock := &stepClock{now: time.Now()}
+	counting := &lazyCountingResolver{delegate: mapResolver{
+		cachingTestGVK(0): {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}}
+	r := NewCachingResolver(counting, CacheOptions{TTL: time.Minute, NegativeTTL: time.Second, Clock: clock})
+
+	if _, err := r.ResolveSchema(cachingTestGVK(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.ResolveSchema(cachingTestGVK(1)); !errors.Is(err, ErrSchemaNotFound) {
+		t.Fatalf("expected ErrSchemaNotFound, got %v", err)
+	}
+	calls := counting.calls
+
+	// the shorter negative TTL expires first
+	clock.Step(2 * time.Second)
+	if _, err := r.ResolveSchema(cachingTestGVK(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.ResolveSchema(cachingTestGVK(1)); !errors.Is(err, ErrSchemaNotFound) {
+		t.Fatalf("expected ErrSchemaNotFound, got %v", err)
+	}
+	if counting.calls != calls+1 {
+		t.Errorf("expected only the not-found entry to expire, got %d extra calls", counting.calls-calls)
+	}
+
+	clock.Step(2 * time.Minute)
+	if _, err := r.ResolveSchema(cachingTestGVK(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counting.calls != calls+2 {
+		t.Errorf("expected the positive entry to expire after its TTL, got %d calls", counting.calls)
+	}
+}
+
+func TestCachingResolverConcurrent(t *testing.T) {
+	defs := mapResolver{}
+	for i := 0; i < 8; i++ {
+		defs[cachingTestGVK(i)] = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	}
+	r := NewCachingResolver(defs, CacheOptions{MaxEntries: 4})
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if _, err := r.ResolveSchema(cachingTestGVK((worker + i) % 8)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+}
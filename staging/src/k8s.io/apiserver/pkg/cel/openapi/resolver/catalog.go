@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// Catalog names accepted by NewCatalogResolver.
+const (
+	// CatalogGatewayAPI bundles schemas for the Gateway API kinds
+	// (gateway.networking.k8s.io/v1).
+	CatalogGatewayAPI = "gateway-api"
+	// CatalogCertManager bundles schemas for the cert-manager kinds
+	// (cert-manager.io/v1).
+	CatalogCertManager = "cert-manager"
+	// CatalogAggregatedMetrics bundles schemas for the well-known aggregated
+	// metrics APIs (metrics.k8s.io, custom.metrics.k8s.io).
+	CatalogAggregatedMetrics = "aggregated-metrics"
+	// CatalogAPIServerConfig bundles schemas for the apiserver configuration
+	// kinds (apiserver.config.k8s.io/v1).
+	CatalogAPIServerConfig = "apiserver-config"
+	// CatalogEvents bundles schemas for the events.k8s.io and audit.k8s.io
+	// Event kinds.
+	CatalogEvents = "events"
+)
+
+// embeddedCatalogs indexes every bundled definition set by catalog name.
+// Each set is a partial, hand-maintained transcription of the published
+// definitions: a kind covers exactly the fields its factory lists, and
+// unlisted fields are absent rather than loosely typed. Policies that touch
+// fields outside that coverage need a discovery-backed resolver instead.
+var embeddedCatalogs = map[string]func() map[schema.GroupVersionKind]*spec.Schema{
+	CatalogGatewayAPI:        gatewayAPISchemas,
+	CatalogCertManager:       certManagerSchemas,
+	CatalogAggregatedMetrics: knownAggregatedSchemas,
+	CatalogAPIServerConfig:   apiServerConfigSchemas,
+	CatalogEvents:            eventSchemas,
+}
+
+// catalogResolver serves embedded schemas from bundled definition sets, so
+// that tooling can type well-known kinds without a live cluster.
+type catalogResolver struct {
+	schemas map[schema.GroupVersionKind]*spec.Schema
+}
+
+var _ SchemaResolver = (*catalogResolver)(nil)
+
+// NewCatalogResolver returns a SchemaResolver preloaded with the bundled
+// definition sets selected by catalog name, e.g. CatalogGatewayAPI. Unknown
+// catalog names are rejected. See embeddedCatalogs for the coverage caveats
+// shared by all bundled sets.
+func NewCatalogResolver(catalogs ...string) (SchemaResolver, error) {
+	schemas := map[schema.GroupVersionKind]*spec.Schema{}
+	for _, catalog := range catalogs {
+		bundled, ok := embeddedCatalogs[catalog]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema catalog %q", catalog)
+		}
+		for gvk, s := range bundled() {
+			schemas[gvk] = s
+		}
+	}
+	return &catalogResolver{schemas: schemas}, nil
+}
+
+func (r *catalogResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, ok := r.schemas[gvk]
+	if !ok {
+		return nil, fmt.Errorf("cannot resolve %v: %w", gvk, ErrSchemaNotFound)
+	}
+	// return a copy so that callers cannot mutate the embedded schema
+	copied := *s
+	return &copied, nil
+}
+
+// objectSchemaWith builds an object schema carrying both the common type meta
+// properties and the given kind-specific properties.
+func objectSchemaWith(typeMeta, props map[string]spec.Schema) *spec.Schema {
+	all := make(map[string]spec.Schema, len(typeMeta)+len(props))
+	for name, s := range typeMeta {
+		all[name] = s
+	}
+	for name, s := range props {
+		all[name] = s
+	}
+	return &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: all,
+	}}
+}
+
+// gatewayAPISchemas covers, besides apiVersion/kind/metadata(name,
+// namespace): Gateway spec.gatewayClassName and
+// spec.listeners[].name/hostname/port/protocol; HTTPRoute spec.parentRefs,
+// spec.hostnames, and spec.rules[].matches[].path/method and
+// rules[].backendRefs[].
+func gatewayAPISchemas() map[schema.GroupVersionKind]*spec.Schema {
+	stringType := *spec.StringProperty()
+	int32Type := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Format: "int32"}}
+	metadata := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name":      stringType,
+			"namespace": stringType,
+		},
+	}}
+	typeMeta := map[string]spec.Schema{
+		"apiVersion": stringType,
+		"kind":       stringType,
+		"metadata":   metadata,
+	}
+	parentRefs := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"array"},
+		Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"group":       stringType,
+				"kind":        stringType,
+				"name":        stringType,
+				"namespace":   stringType,
+				"sectionName": stringType,
+				"port":        int32Type,
+			},
+		}}},
+	}}
+
+	gateway := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"gatewayClassName": stringType,
+				"listeners": {SchemaProps: spec.SchemaProps{
+					Type: []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: map[string]spec.Schema{
+							"name":     stringType,
+							"hostname": stringType,
+							"port":     int32Type,
+							"protocol": stringType,
+						},
+					}}},
+				}},
+			},
+		}},
+	})
+	httpRoute := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"parentRefs": parentRefs,
+				"hostnames": {SchemaProps: spec.SchemaProps{
+					Type:  []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: spec.StringProperty()},
+				}},
+				"rules": {SchemaProps: spec.SchemaProps{
+					Type: []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: map[string]spec.Schema{
+							"matches": {SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+									Properties: map[string]spec.Schema{
+										"path": {SchemaProps: spec.SchemaProps{
+											Type: []string{"object"},
+											Properties: map[string]spec.Schema{
+												"type":  stringType,
+												"value": stringType,
+											},
+										}},
+										"method": stringType,
+									},
+								}}},
+							}},
+							"backendRefs": {SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+									Properties: map[string]spec.Schema{
+										"group":     stringType,
+										"kind":      stringType,
+										"name":      stringType,
+										"namespace": stringType,
+										"port":      int32Type,
+										"weight":    int32Type,
+									},
+								}}},
+							}},
+						},
+					}}},
+				}},
+			},
+		}},
+	})
+
+	gv := schema.GroupVersion{Group: "gateway.networking.k8s.io", Version: "v1"}
+	return map[schema.GroupVersionKind]*spec.Schema{
+		gv.WithKind("Gateway"):   gateway,
+		gv.WithKind("HTTPRoute"): httpRoute,
+	}
+}
+
+// certManagerSchemas covers, besides apiVersion/kind/metadata(name,
+// namespace): Certificate spec.secretName, spec.commonName, spec.dnsNames,
+// spec.duration, spec.renewBefore, and spec.issuerRef.
+func certManagerSchemas() map[schema.GroupVersionKind]*spec.Schema {
+	stringType := *spec.StringProperty()
+	metadata := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name":      stringType,
+			"namespace": stringType,
+		},
+	}}
+	typeMeta := map[string]spec.Schema{
+		"apiVersion": stringType,
+		"kind":       stringType,
+		"metadata":   metadata,
+	}
+
+	certificate := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"secretName": stringType,
+				"commonName": stringType,
+				"dnsNames": {SchemaProps: spec.SchemaProps{
+					Type:  []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: spec.StringProperty()},
+				}},
+				"duration":    stringType,
+				"renewBefore": stringType,
+				"issuerRef": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"group": stringType,
+						"kind":  stringType,
+						"name":  stringType,
+					},
+				}},
+			},
+		}},
+	})
+
+	return map[schema.GroupVersionKind]*spec.Schema{
+		{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}: certificate,
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCatalogResolver(t *testing.T) {
+	r, err := NewCatalogResolver(CatalogGatewayAPI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	httpRoute, err := r.ResolveSchema(schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rules := httpRoute.Properties["spec"].Properties["rules"]
+	if !rules.Type.Contains("array") || rules.Items == nil || rules.Items.Schema == nil {
+		t.Fatal("expected spec.rules to be an array of rules")
+	}
+	backendRefs := rules.Items.Schema.Properties["backendRefs"]
+	if !backendRefs.Items.Schema.Properties["name"].Type.Contains("string") {
+		t.Error("expected backendRefs name to be typed as string")
+	}
+
+	// kinds from unselected catalogs are not served
+	if _, err := r.ResolveSchema(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for an unselected catalog, got %v", err)
+	}
+}
+
+func TestCatalogResolverEmbeddedCatalogs(t *testing.T) {
+	// every bundled definition set is selectable by catalog name and serves
+	// a representative kind.
+	for catalog, gvk := range map[string]schema.GroupVersionKind{
+		CatalogGatewayAPI:        {Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"},
+		CatalogCertManager:       {Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+		CatalogAggregatedMetrics: {Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"},
+		CatalogAPIServerConfig:   {Group: "apiserver.config.k8s.io", Version: "v1", Kind: "AdmissionConfiguration"},
+		CatalogEvents:            {Group: "events.k8s.io", Version: "v1", Kind: "Event"},
+	} {
+		r, err := NewCatalogResolver(catalog)
+		if err != nil {
+			t.Fatalf("unexpected error for catalog %q: %v", catalog, err)
+		}
+		if _, err := r.ResolveSchema(gvk); err != nil {
+			t.Errorf("expected catalog %q to serve %v, got %v", catalog, gvk, err)
+		}
+	}
+}
+
+func TestCatalogResolverUnknownCatalog(t *testing.T) {
+	_, err := NewCatalogResolver(CatalogGatewayAPI, "istio")
+	if err == nil {
+		t.Fatal("expected error for an unknown catalog name, got none")
+	}
+	if !strings.Contains(err.Error(), `"istio"`) {
+		t.Errorf("expected error to name the unknown catalog, got %v", err)
+	}
+}
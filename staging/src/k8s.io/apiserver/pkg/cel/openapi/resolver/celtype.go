@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/common"
+	"k8s.io/apiserver/pkg/cel/openapi"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// CELTypeResolver resolves schemas and converts them into CEL DeclTypes in
+// one step, caching the DeclType per GVK so that the schema-to-DeclType
+// conversion stays out of the hot path.
+type CELTypeResolver struct {
+	delegate SchemaResolver
+
+	mu    sync.RWMutex
+	cache map[schema.GroupVersionKind]*apiservercel.DeclType
+}
+
+// NewCELTypeResolver returns a CELTypeResolver resolving schemas via the
+// given resolver.
+func NewCELTypeResolver(delegate SchemaResolver) *CELTypeResolver {
+	return &CELTypeResolver{
+		delegate: delegate,
+		cache:    map[schema.GroupVersionKind]*apiservercel.DeclType{},
+	}
+}
+
+// ResolveCELType resolves the schema identified by gvk and returns its CEL
+// DeclType. The DeclType is computed once per GVK and served from cache
+// afterwards.
+func (r *CELTypeResolver) ResolveCELType(gvk schema.GroupVersionKind) (*apiservercel.DeclType, error) {
+	r.mu.RLock()
+	declType, ok := r.cache[gvk]
+	r.mu.RUnlock()
+	if ok {
+		return declType, nil
+	}
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	declType = common.SchemaDeclType(&openapi.Schema{Schema: s}, true)
+	if declType == nil {
+		return nil, fmt.Errorf("cannot convert schema of %v to a CEL type", gvk)
+	}
+	declType = declType.MaybeAssignTypeName(gvk.Kind)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cached, ok := r.cache[gvk]; ok {
+		return cached, nil
+	}
+	r.cache[gvk] = declType
+	return declType, nil
+}
+
+// SchemaDeclType converts a resolved schema into the corresponding CEL
+// DeclType: objects become field maps, arrays become lists, objects with
+// additionalProperties become maps, scalars map to their CEL equivalents,
+// and x-kubernetes-int-or-string is honored. The schema must be fully
+// resolved; any remaining ref is reported as an error so callers know to
+// run PopulateRefs first.
+func SchemaDeclType(s *spec.Schema, isResourceRoot bool) (*apiservercel.DeclType, error) {
+	if err := WalkSchema(s, func(path string, node *spec.Schema) error {
+		if ref := node.Ref.String(); ref != "" {
+			return fmt.Errorf("cannot convert schema with unresolved ref %q at %s to a CEL type: populate refs first", ref, displayPath(path))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	declType := common.SchemaDeclType(&openapi.Schema{Schema: s}, isResourceRoot)
+	if declType == nil {
+		return nil, fmt.Errorf("schema does not correspond to a CEL type")
+	}
+	return declType, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// countingResolver counts delegate calls on top of a fixed schema.
+type countingResolver struct {
+	schema *spec.Schema
+	calls  int
+}
+
+func (c *countingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	c.calls++
+	return c.schema, nil
+}
+
+func TestResolveCELType(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	delegate := &countingResolver{schema: &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"name":     {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		},
+	}}
+	r := NewCELTypeResolver(delegate)
+
+	declType, err := r.ResolveCELType(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name, ok := declType.Fields["name"]
+	if !ok {
+		t.Fatal("expected a name field on the DeclType")
+	}
+	if name.Type.TypeName() != "string" {
+		t.Errorf("expected name to be a string, got %s", name.Type.TypeName())
+	}
+	replicas, ok := declType.Fields["replicas"]
+	if !ok {
+		t.Fatal("expected a replicas field on the DeclType")
+	}
+	if replicas.Type.TypeName() != "int" {
+		t.Errorf("expected replicas to be an int, got %s", replicas.Type.TypeName())
+	}
+
+	// repeated calls are served from the cache
+	again, err := r.ResolveCELType(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != declType {
+		t.Error("expected the cached DeclType to be reused")
+	}
+	if delegate.calls != 1 {
+		t.Errorf("expected a single delegate resolution, got %d", delegate.calls)
+	}
+}
+
+func TestSchemaDeclType(t *testing.T) {
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"metadata": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			}},
+			"port": {
+				VendorExtensible: spec.VendorExtensible{Extensions: map[string]interface{}{
+					extIntOrString: true,
+				}},
+			},
+			"tags": {SchemaProps: spec.SchemaProps{
+				Type:  []string{"array"},
+				Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+			}},
+			"labels": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+					SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+				}},
+			}},
+		},
+	}}
+	declType, err := SchemaDeclType(s, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metadata, ok := declType.Fields["metadata"]
+	if !ok {
+		t.Fatal("expected a metadata field on the DeclType")
+	}
+	if name, ok := metadata.Type.Fields["name"]; !ok {
+		t.Fatal("expected a nested name field on metadata")
+	} else if name.Type.TypeName() != "string" {
+		t.Errorf("expected metadata.name to be a string, got %s", name.Type.TypeName())
+	}
+	if port, ok := declType.Fields["port"]; !ok {
+		t.Fatal("expected a port field on the DeclType")
+	} else if port.Type.TypeName() != "dyn" {
+		t.Errorf("expected port to map to dyn (intOrString), got %s", port.Type.TypeName())
+	}
+	if tags, ok := declType.Fields["tags"]; !ok {
+		t.Fatal("expected a tags field on the DeclType")
+	} else if !tags.Type.IsList() {
+		t.Errorf("expected tags to be a list, got %s", tags.Type.TypeName())
+	}
+	if labels, ok := declType.Fields["labels"]; !ok {
+		t.Fatal("expected a labels field on the DeclType")
+	} else if !labels.Type.IsMap() {
+		t.Errorf("expected labels to be a map, got %s", labels.Type.TypeName())
+	}
+}
+
+func TestSchemaDeclTypeUnresolvedRef(t *testing.T) {
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+		},
+	}}
+	if _, err := SchemaDeclType(s, true); err == nil {
+		t.Error("expected an error for a schema with an unresolved ref")
+	} else if !strings.Contains(err.Error(), "/properties/spec") {
+		t.Errorf("expected the error to name the offending path, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// NewAPIServerConfigResolver returns a SchemaResolver preloaded with schemas
+// for the apiserver's own configuration kinds, which are not served by
+// discovery: AdmissionConfiguration and EncryptionConfiguration of
+// apiserver.config.k8s.io/v1. Equivalent to
+// NewCatalogResolver(CatalogAPIServerConfig); the same partial-coverage
+// caveats apply.
+func NewAPIServerConfigResolver() SchemaResolver {
+	return &catalogResolver{schemas: apiServerConfigSchemas()}
+}
+
+// apiServerConfigSchemas covers, besides apiVersion/kind:
+// AdmissionConfiguration plugins[].name/path/configuration;
+// EncryptionConfiguration resources[].resources and resources[].providers[]
+// with the aescbc, aesgcm, secretbox, identity, and kms provider shapes.
+func apiServerConfigSchemas() map[schema.GroupVersionKind]*spec.Schema {
+	stringType := *spec.StringProperty()
+	typeMeta := map[string]spec.Schema{
+		"apiVersion": stringType,
+		"kind":       stringType,
+	}
+	// plugin configuration is free-form
+	anyObject := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		AdditionalProperties: &spec.SchemaOrBool{
+			Allows: true,
+		},
+	}}
+
+	admissionConfiguration := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"plugins": {SchemaProps: spec.SchemaProps{
+			Type: []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type:     []string{"object"},
+				Required: []string{"name"},
+				Properties: map[string]spec.Schema{
+					"name":          stringType,
+					"path":          stringType,
+					"configuration": anyObject,
+				},
+			}}},
+		}},
+	})
+
+	keyList := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"array"},
+		Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"name":   stringType,
+				"secret": stringType,
+			},
+		}}},
+	}}
+	keyedProvider := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"keys": keyList,
+		},
+	}}
+	encryptionConfiguration := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"resources": {SchemaProps: spec.SchemaProps{
+			Type: []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resources": {SchemaProps: spec.SchemaProps{
+						Type:  []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: spec.StringProperty()},
+					}},
+					"providers": {SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							Properties: map[string]spec.Schema{
+								"aescbc":    keyedProvider,
+								"aesgcm":    keyedProvider,
+								"secretbox": keyedProvider,
+								"identity": {SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+								}},
+								"kms": {SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+									Properties: map[string]spec.Schema{
+										"apiVersion": stringType,
+										"name":       stringType,
+										"endpoint":   stringType,
+										"cachesize":  {SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Format: "int32"}},
+										"timeout":    stringType,
+									},
+								}},
+							},
+						}}},
+					}},
+				},
+			}}},
+		}},
+	})
+
+	return map[schema.GroupVersionKind]*spec.Schema{
+		{Group: "apiserver.config.k8s.io", Version: "v1", Kind: "AdmissionConfiguration"}:  admissionConfiguration,
+		{Group: "apiserver.config.k8s.io", Version: "v1", Kind: "EncryptionConfiguration"}: encryptionConfiguration,
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAPIServerConfigResolver(t *testing.T) {
+	r := NewAPIServerConfigResolver()
+
+	s, err := r.ResolveSchema(schema.GroupVersionKind{Group: "apiserver.config.k8s.io", Version: "v1", Kind: "AdmissionConfiguration"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plugins := s.Properties["plugins"]
+	if !plugins.Type.Contains("array") || plugins.Items == nil || plugins.Items.Schema == nil {
+		t.Fatal("expected plugins to be an array of plugin configurations")
+	}
+	if !plugins.Items.Schema.Properties["name"].Type.Contains("string") {
+		t.Error("expected plugin name to be typed as string")
+	}
+
+	if _, err := r.ResolveSchema(schema.GroupVersionKind{Group: "apiserver.config.k8s.io", Version: "v1", Kind: "EncryptionConfiguration"}); err != nil {
+		t.Errorf("unexpected error resolving EncryptionConfiguration: %v", err)
+	}
+	if _, err := r.ResolveSchema(schema.GroupVersionKind{Group: "apiserver.config.k8s.io", Version: "v1", Kind: "TracingConfiguration"}); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for a kind without an embedded schema, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// ContextSchemaResolver is implemented by resolvers whose lookups can block,
+// e.g. on network calls, and that therefore honor a caller-supplied context.
+// Callers should use ResolveSchemaWithContext rather than type-asserting for
+// this interface themselves.
+type ContextSchemaResolver interface {
+	// ResolveSchemaContext behaves like SchemaResolver.ResolveSchema but
+	// aborts with ctx.Err() when the context is done.
+	ResolveSchemaContext(ctx context.Context, gvk schema.GroupVersionKind) (*spec.Schema, error)
+}
+
+// ResolveSchemaWithContext resolves the schema of the given GroupVersionKind
+// under the given context. If r implements ContextSchemaResolver the context
+// is passed through; otherwise the context is checked once up front and the
+// plain ResolveSchema is called.
+func ResolveSchemaWithContext(ctx context.Context, r SchemaResolver, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cr, ok := r.(ContextSchemaResolver); ok {
+		return cr.ResolveSchemaContext(ctx, gvk)
+	}
+	return r.ResolveSchema(gvk)
+}
+
+// PopulateRefsContext behaves like PopulateRefs but aborts the expansion and
+// returns ctx.Err() once the context is done. The context is checked before
+// each ref lookup, so a hanging schemaOf bounds how promptly cancellation is
+// observed. No partial result is returned on cancellation.
+func PopulateRefsContext(ctx context.Context, schemaOf func(ref string) (*spec.Schema, bool), rootRef string) (*spec.Schema, error) {
+	s, err := PopulateRefsWithOptions(schemaOf, rootRef, Options{
+		OnResolveRef: func(string) error { return ctx.Err() },
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return s, err
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// contextMapResolver is a mapResolver that records the context it was
+// resolved under.
+type contextMapResolver struct {
+	mapResolver
+	sawContext bool
+}
+
+func (c *contextMapResolver) ResolveSchemaContext(ctx context.Context, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	c.sawContext = true
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.ResolveSchema(gvk)
+}
+
+func TestResolveSchemaWithContext(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	objectSchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+
+	// plain resolvers fall back to ResolveSchema
+	if _, err := ResolveSchemaWithContext(context.Background(), mapResolver{gvk: objectSchema}, gvk); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// context-aware resolvers receive the context
+	cr := &contextMapResolver{mapResolver: mapResolver{gvk: objectSchema}}
+	if _, err := ResolveSchemaWithContext(context.Background(), cr, gvk); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !cr.sawContext {
+		t.Error("expected the context-aware variant to be used")
+	}
+
+	// a done context short-circuits before any lookup
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ResolveSchemaWithContext(ctx, mapResolver{gvk: objectSchema}, gvk); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPopulateRefsContext(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+				},
+			},
+		},
+		"Spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	if _, err := PopulateRefsContext(context.Background(), schemaOf, "Root"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resolved, err := PopulateRefsContext(ctx, schemaOf, "Root")
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if resolved != nil {
+		t.Errorf("expected no partial result on cancellation, got %v", resolved)
+	}
+}
+
+func TestPopulateRefsContextCancelMidExpansion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+				},
+			},
+		},
+		"Spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "Root" {
+			// simulate the context expiring while the expansion is in flight
+			cancel()
+		}
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	resolved, err := PopulateRefsContext(ctx, schemaOf, "Root")
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if resolved != nil {
+		t.Errorf("expected no partial result on cancellation, got %v", resolved)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// FromJSONSchemaProps converts a CRD openAPIV3Schema into a spec.Schema via
+// its JSON serialization, so that it can flow into PopulateRefs. props is
+// typically a *apiextensionsv1.JSONSchemaProps; any value sharing its JSON
+// shape is accepted, since this package cannot import the apiextensions types
+// without inverting the module dependency. The x-kubernetes extensions are
+// carried over into the schema's Extensions.
+func FromJSONSchemaProps(props interface{}) (*spec.Schema, error) {
+	b, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal JSONSchemaProps: %w", err)
+	}
+	s := new(spec.Schema)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("cannot convert JSONSchemaProps to spec.Schema: %w", err)
+	}
+	return s, nil
+}
+
+// ToJSONSchemaProps converts a spec.Schema back into a CRD openAPIV3Schema by
+// unmarshaling its JSON serialization into props, typically a
+// *apiextensionsv1.JSONSchemaProps.
+func ToJSONSchemaProps(s *spec.Schema, props interface{}) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("cannot marshal spec.Schema: %w", err)
+	}
+	if err := json.Unmarshal(b, props); err != nil {
+		return fmt.Errorf("cannot convert spec.Schema to JSONSchemaProps: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestJSONSchemaPropsRoundTrip(t *testing.T) {
+	original := &spec.Schema{
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+			extValidations: []interface{}{map[string]interface{}{"rule": "self.replicas > 0"}},
+		}},
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{"replicas"},
+			Properties: map[string]spec.Schema{
+				"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Format: "int32"}},
+				"tags": {SchemaProps: spec.SchemaProps{
+					Type: []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+					}},
+				}},
+				"labels": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{
+						Allows: true,
+						Schema: spec.StringProperty(),
+					},
+				}},
+				"config": {VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+					extPreserveUnknownFields: true,
+				}}},
+			},
+		},
+	}
+
+	// a JSONSchemaProps is anything sharing the JSON shape; the round trip
+	// goes through that shape and back
+	var props map[string]interface{}
+	if err := ToJSONSchemaProps(original, &props); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	roundTripped, err := FromJSONSchemaProps(props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(original, roundTripped) {
+		t.Errorf("round trip mismatch:\noriginal: %+v\nround-tripped: %+v", original, roundTripped)
+	}
+
+	// spot-check the intermediate shape matches JSONSchemaProps serialization
+	properties, ok := props["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected properties in the intermediate form, got %v", props)
+	}
+	config, ok := properties["config"].(map[string]interface{})
+	if !ok || config[extPreserveUnknownFields] != true {
+		t.Errorf("expected the x-kubernetes extension in the intermediate form, got %v", properties["config"])
+	}
+}
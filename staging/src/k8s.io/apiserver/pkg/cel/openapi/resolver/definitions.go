@@ -18,11 +18,13 @@ package resolver
 
 import (
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/endpoints/openapi"
 	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/spec3"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
@@ -31,6 +33,12 @@ import (
 type DefinitionsSchemaResolver struct {
 	defs     map[string]common.OpenAPIDefinition
 	gvkToRef map[schema.GroupVersionKind]string
+	// conflicts lists, per GVK, the definition names that all claimed the
+	// GVK during indexing, when there was more than one.
+	conflicts map[schema.GroupVersionKind][]string
+	// nameToGVKs lists, per definition name, every GVK it declares, e.g.
+	// both the internal list and item variants of a type.
+	nameToGVKs map[string][]schema.GroupVersionKind
 }
 
 // NewDefinitionsSchemaResolver creates a new DefinitionsSchemaResolver.
@@ -38,20 +46,79 @@ type DefinitionsSchemaResolver struct {
 // getDefinitions = "k8s.io/kubernetes/pkg/generated/openapi".GetOpenAPIDefinitions
 // scheme         = "k8s.io/client-go/kubernetes/scheme".Scheme
 func NewDefinitionsSchemaResolver(getDefinitions common.GetOpenAPIDefinitions, schemes ...*runtime.Scheme) *DefinitionsSchemaResolver {
-	gvkToRef := make(map[schema.GroupVersionKind]string)
 	namer := openapi.NewDefinitionNamer(schemes...)
 	defs := getDefinitions(spec.MustCreateRef)
-	for name := range defs {
+	gvkToRef, conflicts, nameToGVKs := indexDefinitions(defs, func(name string) []schema.GroupVersionKind {
 		_, e := namer.GetDefinitionName(name)
-		gvks := extensionsToGVKs(e)
-		for _, gvk := range gvks {
-			gvkToRef[gvk] = name
+		return extensionsToGVKs(e)
+	})
+	return &DefinitionsSchemaResolver{
+		gvkToRef:   gvkToRef,
+		defs:       defs,
+		conflicts:  conflicts,
+		nameToGVKs: nameToGVKs,
+	}
+}
+
+// indexDefinitions maps each GVK to the definition that declares it. When
+// several definitions claim the same GVK, the first in name order wins, so
+// resolution is deterministic, and the full sorted name list is recorded as
+// a conflict.
+func indexDefinitions(defs map[string]common.OpenAPIDefinition, gvksOf func(name string) []schema.GroupVersionKind) (map[schema.GroupVersionKind]string, map[schema.GroupVersionKind][]string, map[string][]schema.GroupVersionKind) {
+	gvkToNames := make(map[schema.GroupVersionKind][]string)
+	nameToGVKs := make(map[string][]schema.GroupVersionKind)
+	for name := range defs {
+		for _, gvk := range gvksOf(name) {
+			gvkToNames[gvk] = append(gvkToNames[gvk], name)
+			nameToGVKs[name] = append(nameToGVKs[name], gvk)
 		}
 	}
-	return &DefinitionsSchemaResolver{
-		gvkToRef: gvkToRef,
-		defs:     defs,
+	for _, gvks := range nameToGVKs {
+		sort.Slice(gvks, func(i, j int) bool {
+			return gvks[i].String() < gvks[j].String()
+		})
 	}
+	gvkToRef := make(map[schema.GroupVersionKind]string, len(gvkToNames))
+	conflicts := make(map[schema.GroupVersionKind][]string)
+	for gvk, names := range gvkToNames {
+		sort.Strings(names)
+		gvkToRef[gvk] = names[0]
+		if len(names) > 1 {
+			conflicts[gvk] = names
+		}
+	}
+	return gvkToRef, conflicts, nameToGVKs
+}
+
+// Conflicts returns the GVKs that more than one OpenAPI definition claimed
+// via its x-kubernetes-group-version-kind extension, with the competing
+// definition names in sorted order. Overlaps like these usually indicate an
+// aggregated API server registering types that shadow another's.
+func (d *DefinitionsSchemaResolver) Conflicts() map[schema.GroupVersionKind][]string {
+	return d.conflicts
+}
+
+// GVKsForDefinition returns every GVK the named definition declares via its
+// x-kubernetes-group-version-kind extension, in sorted order; all of them
+// resolve to the definition's schema through ResolveSchema. The result is
+// nil for unknown names and for definitions declaring no GVK.
+func (d *DefinitionsSchemaResolver) GVKsForDefinition(name string) []schema.GroupVersionKind {
+	return d.nameToGVKs[name]
+}
+
+var _ ListableResolver = (*DefinitionsSchemaResolver)(nil)
+
+// ListKinds returns every GVK indexed from the definitions'
+// x-kubernetes-group-version-kind extensions, in sorted order.
+func (d *DefinitionsSchemaResolver) ListKinds() ([]schema.GroupVersionKind, error) {
+	gvks := make([]schema.GroupVersionKind, 0, len(d.gvkToRef))
+	for gvk := range d.gvkToRef {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool {
+		return gvks[i].String() < gvks[j].String()
+	})
+	return gvks, nil
 }
 
 func (d *DefinitionsSchemaResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
@@ -61,7 +128,7 @@ func (d *DefinitionsSchemaResolver) ResolveSchema(gvk schema.GroupVersionKind) (
 	}
 	s, err := PopulateRefs(func(ref string) (*spec.Schema, bool) {
 		// find the schema by the ref string, and return a deep copy
-		def, ok := d.defs[ref]
+		def, ok := d.defs[CanonicalizeRef(ref)]
 		if !ok {
 			return nil, false
 		}
@@ -74,6 +141,27 @@ func (d *DefinitionsSchemaResolver) ResolveSchema(gvk schema.GroupVersionKind) (
 	return s, nil
 }
 
+// ResolveSchemaBoth works like ResolveSchema but additionally returns the raw,
+// ref-bearing schema as it appears in the OpenAPI definitions. This makes it
+// easy to diff the two and understand what resolution changed for a type.
+func (d *DefinitionsSchemaResolver) ResolveSchemaBoth(gvk schema.GroupVersionKind) (resolved, raw *spec.Schema, err error) {
+	ref, ok := d.gvkToRef[gvk]
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot resolve %v: %w", gvk, ErrSchemaNotFound)
+	}
+	def, ok := d.defs[ref]
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot resolve %v: %w", gvk, ErrSchemaNotFound)
+	}
+	// return a copy so that callers cannot mutate the stored definition
+	rawCopy := def.Schema
+	resolved, err = d.ResolveSchema(gvk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resolved, &rawCopy, nil
+}
+
 func extensionsToGVKs(extensions spec.Extensions) []schema.GroupVersionKind {
 	gvksAny, ok := extensions[extGVK]
 	if !ok {
@@ -110,3 +198,29 @@ func extensionsToGVKs(extensions spec.Extensions) []schema.GroupVersionKind {
 	}
 	return result
 }
+
+// NewDefinitionsSchemaResolverV3 creates a DefinitionsSchemaResolver from an
+// OpenAPI v3 document, indexing components.schemas and mapping GVKs via the
+// schemas' x-kubernetes-group-version-kind extensions. Refs inside the
+// document stay in the v3 "#/components/schemas/..." form and are followed
+// during resolution.
+func NewDefinitionsSchemaResolverV3(doc *spec3.OpenAPI) *DefinitionsSchemaResolver {
+	defs := make(map[string]common.OpenAPIDefinition)
+	if doc.Components != nil {
+		for name, s := range doc.Components.Schemas {
+			if s == nil {
+				continue
+			}
+			defs[name] = common.OpenAPIDefinition{Schema: *s}
+		}
+	}
+	gvkToRef, conflicts, nameToGVKs := indexDefinitions(defs, func(name string) []schema.GroupVersionKind {
+		return extensionsToGVKs(defs[name].Schema.Extensions)
+	})
+	return &DefinitionsSchemaResolver{
+		gvkToRef:   gvkToRef,
+		defs:       defs,
+		conflicts:  conflicts,
+		nameToGVKs: nameToGVKs,
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// testGVK is the GroupVersionKind used by testDefinitionsResolver.
+var testGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+// testDefinitionsResolver returns a DefinitionsSchemaResolver with a small
+// hand-written set of definitions where the root type refers to a second
+// definition.
+func testDefinitionsResolver() *DefinitionsSchemaResolver {
+	defs := map[string]common.OpenAPIDefinition{
+		"com.example.v1.Widget": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"spec": {SchemaProps: spec.SchemaProps{
+							Ref: spec.MustCreateRef("com.example.v1.WidgetSpec"),
+						}},
+					},
+				},
+			},
+		},
+		"com.example.v1.WidgetSpec": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					},
+				},
+			},
+		},
+	}
+	return &DefinitionsSchemaResolver{
+		defs: defs,
+		gvkToRef: map[schema.GroupVersionKind]string{
+			testGVK: "com.example.v1.Widget",
+		},
+	}
+}
+
+// countRefs walks the schema and returns the number of Refs it carries.
+func countRefs(s *spec.Schema) int {
+	if s == nil {
+		return 0
+	}
+	count := 0
+	if s.Ref.GetURL() != nil {
+		count++
+	}
+	for _, prop := range s.Properties {
+		count += countRefs(&prop)
+	}
+	for _, allOf := range s.AllOf {
+		count += countRefs(&allOf)
+	}
+	if s.AdditionalProperties != nil {
+		count += countRefs(s.AdditionalProperties.Schema)
+	}
+	if s.Items != nil {
+		count += countRefs(s.Items.Schema)
+		for _, item := range s.Items.Schemas {
+			count += countRefs(&item)
+		}
+	}
+	return count
+}
+
+func TestResolveSchemaBoth(t *testing.T) {
+	d := testDefinitionsResolver()
+	resolved, raw, err := d.ResolveSchemaBoth(testGVK)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countRefs(raw); got == 0 {
+		t.Error("expected the raw schema to still contain refs")
+	}
+	if got := countRefs(resolved); got != 0 {
+		t.Errorf("expected the resolved schema to contain no refs, found %d", got)
+	}
+	if !resolved.Properties["spec"].Properties["replicas"].Type.Contains("integer") {
+		t.Error("expected spec.replicas to be resolved to an integer")
+	}
+}
+
+func TestResolveListKind(t *testing.T) {
+	// list wrappers carry their element type as a ref into another
+	// definition; resolving the List kind must fully resolve the element
+	listGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "WidgetList"}
+	d := testDefinitionsResolver()
+	d.defs["com.example.v1.WidgetList"] = common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiVersion": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"kind":       {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"items": {SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("com.example.v1.Widget")},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	d.gvkToRef[listGVK] = "com.example.v1.WidgetList"
+
+	resolved, err := d.ResolveSchema(listGVK)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countRefs(resolved); got != 0 {
+		t.Errorf("expected the resolved list schema to contain no refs, found %d", got)
+	}
+	items := resolved.Properties["items"]
+	if items.Items == nil || items.Items.Schema == nil {
+		t.Fatal("expected items to carry an element schema")
+	}
+	element := items.Items.Schema
+	if !element.Properties["spec"].Properties["replicas"].Type.Contains("integer") {
+		t.Error("expected the items element to be the fully-resolved element type")
+	}
+}
+
+func TestResolveSchemaBothUnknownGVK(t *testing.T) {
+	d := testDefinitionsResolver()
+	unknown := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Missing"}
+	if _, _, err := d.ResolveSchemaBoth(unknown); err == nil {
+		t.Error("expected error for unknown GVK, got none")
+	}
+}
+
+func TestIndexDefinitionsConflicts(t *testing.T) {
+	objectDef := common.OpenAPIDefinition{Schema: spec.Schema{
+		SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+	}}
+	defs := map[string]common.OpenAPIDefinition{
+		"com.example.v1.Widget":  objectDef,
+		"com.example2.v1.Widget": objectDef,
+		"com.example.v1.Gadget":  objectDef,
+	}
+	gvkOfName := map[string]schema.GroupVersionKind{
+		"com.example.v1.Widget":  testGVK,
+		"com.example2.v1.Widget": testGVK,
+		"com.example.v1.Gadget":  {Group: "example.com", Version: "v1", Kind: "Gadget"},
+	}
+	gvkToRef, conflicts, _ := indexDefinitions(defs, func(name string) []schema.GroupVersionKind {
+		return []schema.GroupVersionKind{gvkOfName[name]}
+	})
+
+	if got := gvkToRef[testGVK]; got != "com.example.v1.Widget" {
+		t.Errorf("expected the first name in sorted order to win, got %q", got)
+	}
+	want := []string{"com.example.v1.Widget", "com.example2.v1.Widget"}
+	if !reflect.DeepEqual(conflicts[testGVK], want) {
+		t.Errorf("expected conflict %v, got %v", want, conflicts[testGVK])
+	}
+	if len(conflicts) != 1 {
+		t.Errorf("expected only the doubly-claimed GVK to conflict, got %v", conflicts)
+	}
+}
+
+func TestListKinds(t *testing.T) {
+	resolver := testDefinitionsResolver()
+	otherGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+	resolver.gvkToRef[otherGVK] = "com.example.v1.Widget"
+
+	gvks, err := resolver.ListKinds()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []schema.GroupVersionKind{otherGVK, testGVK}
+	if !reflect.DeepEqual(gvks, want) {
+		t.Errorf("expected %v in sorted order, got %v", want, gvks)
+	}
+}
+
+func TestNewDefinitionsSchemaResolverV3(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	doc := &spec3.OpenAPI{
+		Components: &spec3.Components{
+			Schemas: map[string]*spec.Schema{
+				"com.example.v1.Widget": {
+					VendorExtensible: spec.VendorExtensible{Extensions: map[string]interface{}{
+						extGVK: []any{map[string]any{
+							"group":   "example.com",
+							"version": "v1",
+							"kind":    "Widget",
+						}},
+					}},
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: map[string]spec.Schema{
+							"spec": {SchemaProps: spec.SchemaProps{
+								Ref: spec.MustCreateRef("#/components/schemas/com.example.v1.WidgetSpec"),
+							}},
+						},
+					},
+				},
+				"com.example.v1.WidgetSpec": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					},
+				}},
+			},
+		},
+	}
+	r := NewDefinitionsSchemaResolverV3(doc)
+
+	resolved, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	specProp, ok := resolved.Properties["spec"]
+	if !ok {
+		t.Fatal("expected a spec property")
+	}
+	if specProp.Ref.GetURL() != nil {
+		t.Error("expected the nested v3 ref to be resolved")
+	}
+	if _, ok := specProp.Properties["replicas"]; !ok {
+		t.Errorf("expected the referred schema to be substituted, got %v", specProp)
+	}
+
+	if _, err := r.ResolveSchema(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for an unknown kind, got %v", err)
+	}
+}
+
+func TestGVKsForDefinition(t *testing.T) {
+	widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	widgetList := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "WidgetList"}
+	doc := &spec3.OpenAPI{
+		Components: &spec3.Components{
+			Schemas: map[string]*spec.Schema{
+				"com.example.v1.Widget": {
+					VendorExtensible: spec.VendorExtensible{Extensions: map[string]interface{}{
+						extGVK: []any{
+							map[string]any{"group": "example.com", "version": "v1", "kind": "Widget"},
+							map[string]any{"group": "example.com", "version": "v1", "kind": "WidgetList"},
+						},
+					}},
+					SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+				},
+			},
+		},
+	}
+	r := NewDefinitionsSchemaResolverV3(doc)
+
+	gvks := r.GVKsForDefinition("com.example.v1.Widget")
+	if want := []schema.GroupVersionKind{widget, widgetList}; !reflect.DeepEqual(gvks, want) {
+		t.Errorf("expected %v, got %v", want, gvks)
+	}
+
+	// both GVKs resolve to the same schema
+	first, err := r.ResolveSchema(widget)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := r.ResolveSchema(widgetList)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("expected both declared GVKs to resolve to the same schema")
+	}
+
+	if gvks := r.GVKsForDefinition("com.example.v1.Unknown"); gvks != nil {
+		t.Errorf("expected nil for an unknown definition, got %v", gvks)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// SchemaChangeKind classifies a difference between two schema versions.
+type SchemaChangeKind string
+
+const (
+	// SchemaChangeAdded marks a field present only in the newer schema.
+	SchemaChangeAdded SchemaChangeKind = "added"
+	// SchemaChangeRemoved marks a field present only in the older schema.
+	SchemaChangeRemoved SchemaChangeKind = "removed"
+	// SchemaChangeChanged marks a field whose type changed.
+	SchemaChangeChanged SchemaChangeKind = "changed"
+)
+
+// SchemaChange is one difference between two resolved schemas of a kind.
+type SchemaChange struct {
+	// Path is the field path at which the schemas differ.
+	Path string
+	// Kind classifies the difference.
+	Kind SchemaChangeKind
+	// Detail describes the difference, e.g. the old and new types.
+	Detail string
+}
+
+// SchemaDiff reports the structural differences between two resolved schemas
+// of the same kind: fields added, fields removed, and fields whose type
+// changed.
+func SchemaDiff(older, newer *spec.Schema) []SchemaChange {
+	var changes []SchemaChange
+	diffSchemas(older, newer, "", &changes)
+	return changes
+}
+
+func diffSchemas(older, newer *spec.Schema, path string, changes *[]SchemaChange) {
+	oldType := strings.Join(older.Type, "|")
+	newType := strings.Join(newer.Type, "|")
+	if oldType != newType {
+		*changes = append(*changes, SchemaChange{
+			Path:   displayPath(path),
+			Kind:   SchemaChangeChanged,
+			Detail: fmt.Sprintf("type changed from %q to %q", oldType, newType),
+		})
+		return
+	}
+	names := sets.KeySet(older.Properties).Union(sets.KeySet(newer.Properties))
+	for _, name := range sets.List(names) {
+		oldProp, inOld := older.Properties[name]
+		newProp, inNew := newer.Properties[name]
+		childAt := childPath(path, name)
+		switch {
+		case !inNew:
+			*changes = append(*changes, SchemaChange{Path: childAt, Kind: SchemaChangeRemoved, Detail: "field removed"})
+		case !inOld:
+			*changes = append(*changes, SchemaChange{Path: childAt, Kind: SchemaChangeAdded, Detail: "field added"})
+		default:
+			diffSchemas(&oldProp, &newProp, childAt, changes)
+		}
+	}
+	if older.Items != nil && older.Items.Schema != nil && newer.Items != nil && newer.Items.Schema != nil {
+		diffSchemas(older.Items.Schema, newer.Items.Schema, path+"[*]", changes)
+	}
+	if older.AdditionalProperties != nil && older.AdditionalProperties.Schema != nil &&
+		newer.AdditionalProperties != nil && newer.AdditionalProperties.Schema != nil {
+		diffSchemas(older.AdditionalProperties.Schema, newer.AdditionalProperties.Schema, path+"[*]", changes)
+	}
+}
+
+// CompatibilityMatrix computes the pairwise SchemaDiff between every version
+// of a kind, keyed by source then target version, to help plan conversions.
+// The diagonal is omitted.
+func CompatibilityMatrix(schemas map[string]*spec.Schema) map[string]map[string][]SchemaChange {
+	matrix := make(map[string]map[string][]SchemaChange, len(schemas))
+	for _, from := range sets.List(sets.KeySet(schemas)) {
+		matrix[from] = make(map[string][]SchemaChange, len(schemas)-1)
+		for _, to := range sets.List(sets.KeySet(schemas)) {
+			if from == to {
+				continue
+			}
+			matrix[from][to] = SchemaDiff(schemas[from], schemas[to])
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func versionedSchema(props map[string]spec.Schema) *spec.Schema {
+	return &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: props,
+	}}
+}
+
+func TestCompatibilityMatrix(t *testing.T) {
+	stringType := *spec.StringProperty()
+	intType := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}}}
+	schemas := map[string]*spec.Schema{
+		"v1": versionedSchema(map[string]spec.Schema{
+			"name": stringType,
+		}),
+		"v2": versionedSchema(map[string]spec.Schema{
+			"name":     stringType,
+			"replicas": intType,
+		}),
+		"v3": versionedSchema(map[string]spec.Schema{
+			"name": stringType,
+			// replicas changed type in v3
+			"replicas": stringType,
+		}),
+	}
+
+	matrix := CompatibilityMatrix(schemas)
+	if len(matrix) != 3 {
+		t.Fatalf("expected a row per version, got %v", matrix)
+	}
+	for from, row := range matrix {
+		if len(row) != 2 {
+			t.Errorf("expected 2 targets for %s, got %v", from, row)
+		}
+		if _, ok := row[from]; ok {
+			t.Errorf("expected no diagonal entry for %s", from)
+		}
+	}
+
+	v1v2 := matrix["v1"]["v2"]
+	if len(v1v2) != 1 || v1v2[0].Kind != SchemaChangeAdded || v1v2[0].Path != "replicas" {
+		t.Errorf("expected replicas to be added from v1 to v2, got %v", v1v2)
+	}
+	v2v1 := matrix["v2"]["v1"]
+	if len(v2v1) != 1 || v2v1[0].Kind != SchemaChangeRemoved || v2v1[0].Path != "replicas" {
+		t.Errorf("expected replicas to be removed from v2 to v1, got %v", v2v1)
+	}
+	v2v3 := matrix["v2"]["v3"]
+	if len(v2v3) != 1 || v2v3[0].Kind != SchemaChangeChanged || v2v3[0].Path != "replicas" {
+		t.Errorf("expected replicas type change from v2 to v3, got %v", v2v3)
+	}
+}
+
+func TestSchemaDiffNested(t *testing.T) {
+	older := versionedSchema(map[string]spec.Schema{
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"mode": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		}},
+	})
+	newer := versionedSchema(map[string]spec.Schema{
+		"spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"mode": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		}},
+	})
+	changes := SchemaDiff(older, newer)
+	if len(changes) != 1 || changes[0].Path != "spec.mode" || changes[0].Kind != SchemaChangeChanged {
+		t.Errorf("expected a nested type change at spec.mode, got %v", changes)
+	}
+}
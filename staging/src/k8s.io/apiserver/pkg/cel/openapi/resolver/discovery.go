@@ -19,17 +19,25 @@ package resolver
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
 // ClientDiscoveryResolver uses client-go discovery to resolve schemas at run time.
+// Fetched GroupVersion documents are cached, so resolving many kinds of the
+// same GroupVersion hits the network once. The zero value (with Discovery
+// set) is ready to use.
 type ClientDiscoveryResolver struct {
 	Discovery discovery.DiscoveryInterface
+
+	mu sync.Mutex
+	// docs caches the parsed per-GroupVersion documents by resource path.
+	docs map[string]*schemaResponse
 }
 
 var _ SchemaResolver = (*ClientDiscoveryResolver)(nil)
@@ -44,12 +52,7 @@ func (r *ClientDiscoveryResolver) ResolveSchema(gvk schema.GroupVersionKind) (*s
 	if !ok {
 		return nil, fmt.Errorf("cannot resolve group version %q: %w", gvk.GroupVersion(), ErrSchemaNotFound)
 	}
-	b, err := c.Schema(runtime.ContentTypeJSON)
-	if err != nil {
-		return nil, err
-	}
-	resp := new(schemaResponse)
-	err = json.Unmarshal(b, resp)
+	resp, err := r.cachedSchemaResponse(resourcePath, c)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +61,7 @@ func (r *ClientDiscoveryResolver) ResolveSchema(gvk schema.GroupVersionKind) (*s
 		return nil, err
 	}
 	s, err := PopulateRefs(func(ref string) (*spec.Schema, bool) {
-		s, ok := resp.Components.Schemas[strings.TrimPrefix(ref, refPrefix)]
+		s, ok := resp.Components.Schemas[CanonicalizeRef(ref)]
 		return s, ok
 	}, ref)
 	if err != nil {
@@ -67,6 +70,30 @@ func (r *ClientDiscoveryResolver) ResolveSchema(gvk schema.GroupVersionKind) (*s
 	return s, nil
 }
 
+// cachedSchemaResponse returns the parsed document for the given resource
+// path, fetching and caching it on first use. Fetch and parse errors are not
+// cached; the next call retries.
+func (r *ClientDiscoveryResolver) cachedSchemaResponse(resourcePath string, gv openapi.GroupVersion) (*schemaResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if resp, ok := r.docs[resourcePath]; ok {
+		return resp, nil
+	}
+	b, err := gv.Schema(runtime.ContentTypeJSON)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(schemaResponse)
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	if r.docs == nil {
+		r.docs = make(map[string]*schemaResponse)
+	}
+	r.docs[resourcePath] = resp
+	return resp, nil
+}
+
 func resolveRef(resp *schemaResponse, gvk schema.GroupVersionKind) (string, error) {
 	for ref, s := range resp.Components.Schemas {
 		var gvks []schema.GroupVersionKind
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeGroupVersion serves a fixed OpenAPI v3 document and counts fetches.
+type fakeGroupVersion struct {
+	doc     []byte
+	fetches int
+}
+
+func (f *fakeGroupVersion) Schema(contentType string) ([]byte, error) {
+	f.fetches++
+	return f.doc, nil
+}
+
+func (f *fakeGroupVersion) ServerRelativeURL() string {
+	return ""
+}
+
+func TestResourcePathFromGV(t *testing.T) {
+	if got := resourcePathFromGV(schema.GroupVersion{Version: "v1"}); got != "api/v1" {
+		t.Errorf("expected the core group special case api/v1, got %q", got)
+	}
+	if got := resourcePathFromGV(schema.GroupVersion{Group: "apps", Version: "v1"}); got != "apis/apps/v1" {
+		t.Errorf("expected apis/apps/v1, got %q", got)
+	}
+}
+
+func TestCachedSchemaResponse(t *testing.T) {
+	gv := &fakeGroupVersion{doc: []byte(`{
+		"components": {
+			"schemas": {
+				"io.k8s.api.apps.v1.Deployment": {
+					"type": "object",
+					"x-kubernetes-group-version-kind": [
+						{"group": "apps", "version": "v1", "kind": "Deployment"}
+					]
+				}
+			}
+		}
+	}`)}
+	r := &ClientDiscoveryResolver{}
+
+	first, err := r.cachedSchemaResponse("apis/apps/v1", gv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := r.cachedSchemaResponse("apis/apps/v1", gv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gv.fetches != 1 {
+		t.Errorf("expected the document to be fetched once, got %d fetches", gv.fetches)
+	}
+	if first != second {
+		t.Error("expected the cached document to be reused")
+	}
+
+	// the parsed document resolves the kind it declares, and misses report
+	// a not-found error
+	if _, err := resolveRef(first, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err = resolveRef(first, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"})
+	if !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for an absent kind, got %v", err)
+	}
+}
+
+func TestCachedSchemaResponseDoesNotCacheErrors(t *testing.T) {
+	gv := &fakeGroupVersion{doc: []byte(`not json`)}
+	r := &ClientDiscoveryResolver{}
+	if _, err := r.cachedSchemaResponse("apis/apps/v1", gv); err == nil {
+		t.Fatal("expected a parse error")
+	}
+	gv.doc = []byte(`{"components": {"schemas": {}}}`)
+	if _, err := r.cachedSchemaResponse("apis/apps/v1", gv); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+	if gv.fetches != 2 {
+		t.Errorf("expected 2 fetches, got %d", gv.fetches)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// DumpSchema renders a resolved schema as an indented tree for debugging,
+// one line per field of the form "name: type (required, constraint, ...)".
+// It is far more readable than the JSON serialization for understanding the
+// structure of a schema. Array items and map values appear as "[*]" children.
+func DumpSchema(s *spec.Schema) string {
+	var b strings.Builder
+	dumpSchema(&b, "<root>", s, 0, false)
+	return b.String()
+}
+
+func dumpSchema(b *strings.Builder, name string, s *spec.Schema, depth int, required bool) {
+	notes := constraintNotes(s)
+	if required {
+		notes = append([]string{"required"}, notes...)
+	}
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteString(name)
+	b.WriteString(": ")
+	b.WriteString(typeLabel(s))
+	if len(notes) > 0 {
+		b.WriteString(" (")
+		b.WriteString(strings.Join(notes, ", "))
+		b.WriteString(")")
+	}
+	b.WriteString("\n")
+
+	requiredNames := sets.New(s.Required...)
+	for _, propName := range sets.List(sets.KeySet(s.Properties)) {
+		prop := s.Properties[propName]
+		dumpSchema(b, propName, &prop, depth+1, requiredNames.Has(propName))
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		dumpSchema(b, "[*]", s.AdditionalProperties.Schema, depth+1, false)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		dumpSchema(b, "[*]", s.Items.Schema, depth+1, false)
+	}
+}
+
+// typeLabel names the type of a schema node, falling back to the type its
+// structural keywords imply, and "map" for objects typed solely by their
+// additionalProperties.
+func typeLabel(s *spec.Schema) string {
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil && len(s.Properties) == 0 {
+		return "map"
+	}
+	if len(s.Type) > 0 {
+		return strings.Join(s.Type, "|")
+	}
+	if inferred := implicitType(s); len(inferred) > 0 {
+		return inferred
+	}
+	return "any"
+}
+
+// constraintNotes summarizes the constraints declared on a single schema node.
+func constraintNotes(s *spec.Schema) []string {
+	var notes []string
+	if len(s.Format) > 0 {
+		notes = append(notes, "format="+s.Format)
+	}
+	if len(s.Enum) > 0 {
+		notes = append(notes, fmt.Sprintf("enum=%v", s.Enum))
+	}
+	if len(s.Pattern) > 0 {
+		notes = append(notes, fmt.Sprintf("pattern=%q", s.Pattern))
+	}
+	if s.Minimum != nil {
+		notes = append(notes, fmt.Sprintf("minimum=%v", *s.Minimum))
+	}
+	if s.Maximum != nil {
+		notes = append(notes, fmt.Sprintf("maximum=%v", *s.Maximum))
+	}
+	if s.MinLength != nil {
+		notes = append(notes, fmt.Sprintf("minLength=%d", *s.MinLength))
+	}
+	if s.MaxLength != nil {
+		notes = append(notes, fmt.Sprintf("maxLength=%d", *s.MaxLength))
+	}
+	if s.MinItems != nil {
+		notes = append(notes, fmt.Sprintf("minItems=%d", *s.MinItems))
+	}
+	if s.MaxItems != nil {
+		notes = append(notes, fmt.Sprintf("maxItems=%d", *s.MaxItems))
+	}
+	if s.MinProperties != nil {
+		notes = append(notes, fmt.Sprintf("minProperties=%d", *s.MinProperties))
+	}
+	if s.MaxProperties != nil {
+		notes = append(notes, fmt.Sprintf("maxProperties=%d", *s.MaxProperties))
+	}
+	return notes
+}
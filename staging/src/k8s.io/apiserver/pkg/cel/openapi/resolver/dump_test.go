@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestDumpSchema(t *testing.T) {
+	one := 1.0
+	maxReplicas := int64(100)
+	s := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{"spec"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type:     []string{"object"},
+					Required: []string{"replicas"},
+					Properties: map[string]spec.Schema{
+						"replicas": {SchemaProps: spec.SchemaProps{
+							Type:    []string{"integer"},
+							Minimum: &one,
+						}},
+						"containers": {SchemaProps: spec.SchemaProps{
+							Type:     []string{"array"},
+							MaxItems: &maxReplicas,
+							Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+								SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+									Properties: map[string]spec.Schema{
+										"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+									},
+								},
+							}},
+						}},
+						"labels": {SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+								SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	dump := DumpSchema(s)
+	for _, line := range []string{
+		"<root>: object\n",
+		"  spec: object (required)\n",
+		"    replicas: integer (required, minimum=1)\n",
+		"    containers: array (maxItems=100)\n",
+		"      [*]: object\n",
+		"        name: string\n",
+		"    labels: map\n",
+		"      [*]: string\n",
+	} {
+		if !strings.Contains(dump, line) {
+			t.Errorf("expected dump to contain %q, got:\n%s", line, dump)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	"k8s.io/apiserver/pkg/cel/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const extEmbeddedResource = "x-kubernetes-embedded-resource"
+
+// ResolveEmbeddedResource returns the schema of a node that is marked with the
+// x-kubernetes-embedded-resource extension, augmented with the standard
+// embedded TypeMeta (apiVersion, kind) and ObjectMeta properties so that CEL
+// types the embedded object correctly.
+// It returns an error if the schema does not carry the extension.
+// The original schema is not mutated; a shallow copy is returned if the
+// schema needs augmenting.
+func ResolveEmbeddedResource(s *spec.Schema) (*spec.Schema, error) {
+	embedded, ok := s.Extensions.GetBool(extEmbeddedResource)
+	if !ok || !embedded {
+		return nil, fmt.Errorf("schema is not marked with %s", extEmbeddedResource)
+	}
+	return common.WithTypeAndObjectMeta(s), nil
+}
+
+// FindEmbeddedResources returns the paths of every subschema marked with
+// x-kubernetes-embedded-resource: true, descending into properties, items,
+// additionalProperties, and combinators. The paths are JSON-pointer style,
+// with "" denoting the root. It operates on already-resolved schemas;
+// unresolved refs are not followed, so any embedded resources behind them are
+// not reported.
+func FindEmbeddedResources(s *spec.Schema) []string {
+	var paths []string
+	// WalkSchema only fails when the callback does, so the error is impossible
+	_ = WalkSchema(s, func(path string, node *spec.Schema) error {
+		if embedded, ok := node.Extensions.GetBool(extEmbeddedResource); ok && embedded {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestResolveEmbeddedResource(t *testing.T) {
+	embedded := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+		},
+		VendorExtensible: spec.VendorExtensible{
+			Extensions: spec.Extensions{
+				extEmbeddedResource: true,
+			},
+		},
+	}
+	result, err := ResolveEmbeddedResource(embedded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"apiVersion", "kind", "metadata"} {
+		if _, ok := result.Properties[name]; !ok {
+			t.Errorf("expected property %q on the embedded resource schema", name)
+		}
+	}
+	if !result.Properties["metadata"].Properties["name"].Type.Contains("string") {
+		t.Error("expected metadata.name to be typed as string")
+	}
+	if _, ok := result.Properties["spec"]; !ok {
+		t.Error("expected original properties to be preserved")
+	}
+	// the original schema must not be mutated
+	if _, ok := embedded.Properties["apiVersion"]; ok {
+		t.Error("original schema was mutated")
+	}
+}
+
+func TestResolveEmbeddedResourceNotMarked(t *testing.T) {
+	plain := &spec.Schema{
+		SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+	}
+	if _, err := ResolveEmbeddedResource(plain); err == nil {
+		t.Error("expected error for a schema without the embedded-resource extension")
+	}
+}
+
+func TestFindEmbeddedResources(t *testing.T) {
+	embedded := spec.VendorExtensible{Extensions: map[string]interface{}{
+		extEmbeddedResource: true,
+	}}
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"template": {
+				VendorExtensible: embedded,
+				SchemaProps:      spec.SchemaProps{Type: []string{"object"}},
+			},
+			"manifests": {SchemaProps: spec.SchemaProps{
+				Type: []string{"array"},
+				Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+					VendorExtensible: embedded,
+					SchemaProps:      spec.SchemaProps{Type: []string{"object"}},
+				}},
+			}},
+			"overrides": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+					VendorExtensible: embedded,
+					SchemaProps:      spec.SchemaProps{Type: []string{"object"}},
+				}},
+			}},
+			"plain": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		},
+	}}
+	got := FindEmbeddedResources(s)
+	want := []string{
+		"/properties/manifests/items",
+		"/properties/overrides/additionalProperties",
+		"/properties/template",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFindEmbeddedResourcesNone(t *testing.T) {
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	if got := FindEmbeddedResources(s); len(got) != 0 {
+		t.Errorf("expected no embedded resources, got %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// EncodeResolved encodes a resolved schema into a compact binary form
+// suitable for disk caches. The encoding is gzip-compressed JSON, so it
+// round-trips everything the schema's JSON serialization carries, including
+// vendor extensions.
+func EncodeResolved(s *spec.Schema) ([]byte, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeResolved decodes a schema previously encoded with EncodeResolved.
+func DecodeResolved(data []byte) (*spec.Schema, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	s := new(spec.Schema)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MarshalSchemaCanonical serializes a resolved schema with every object's
+// keys sorted, including nested property maps, so that byte-for-byte golden
+// comparisons of resolved schemas are stable across runs. Refs render in
+// their usual {"$ref": ...} form. The canonical form is produced by
+// round-tripping the schema's JSON through generic maps, whose keys
+// encoding/json emits in sorted order.
+func MarshalSchemaCanonical(s *spec.Schema) ([]byte, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
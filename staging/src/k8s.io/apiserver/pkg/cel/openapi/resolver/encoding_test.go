@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestEncodeDecodeResolvedRoundTrip(t *testing.T) {
+	original := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:        []string{"object"},
+			Description: "a widget",
+			Required:    []string{"spec"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"containers": {
+							SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+								}},
+							},
+							VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+								extListType:    "map",
+								extListMapKeys: []interface{}{"name"},
+							}},
+						},
+					},
+				}},
+			},
+		},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+			extGVK: []interface{}{map[string]interface{}{
+				"group": "example.com", "version": "v1", "kind": "Widget",
+			}},
+		}},
+	}
+
+	encoded, err := EncodeResolved(original)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	decoded, err := DecodeResolved(encoded)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+
+	// compare via the canonical JSON form, which is how schemas are consumed
+	wantHash, err := SchemaHash(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotHash, err := SchemaHash(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wantHash != gotHash {
+		t.Error("decoded schema does not round-trip to the original serialization")
+	}
+	if got, ok := decoded.Extensions.GetString(extListType); ok && got != "" {
+		t.Errorf("unexpected extension on root: %v", got)
+	}
+	containers := decoded.Properties["spec"].Properties["containers"]
+	if got, _ := containers.Extensions.GetString(extListType); got != "map" {
+		t.Errorf("expected nested extension to round-trip, got %q", got)
+	}
+	if keys, _ := containers.Extensions.GetStringSlice(extListMapKeys); !reflect.DeepEqual(keys, []string{"name"}) {
+		t.Errorf("expected list-map keys to round-trip, got %v", keys)
+	}
+}
+
+func TestDecodeResolvedRejectsGarbage(t *testing.T) {
+	if _, err := DecodeResolved([]byte("not gzip")); err == nil {
+		t.Error("expected error decoding garbage, got none")
+	}
+}
+
+func TestMarshalSchemaCanonical(t *testing.T) {
+	build := func() *spec.Schema {
+		return &spec.Schema{SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"zeta": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"alpha": {SchemaProps: spec.SchemaProps{
+					Type: []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							Properties: map[string]spec.Schema{
+								"b": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+								"a": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+							},
+						},
+					}},
+				}},
+				"mid": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Other")},
+					}},
+				}},
+			},
+		}}
+	}
+
+	first, err := MarshalSchemaCanonical(build())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := MarshalSchemaCanonical(build())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("expected identical canonical output, got %s and %s", first, second)
+	}
+
+	out := string(first)
+	if strings.Index(out, `"alpha"`) > strings.Index(out, `"zeta"`) {
+		t.Errorf("expected property keys in sorted order, got %s", out)
+	}
+	if !strings.Contains(out, `"$ref":"#/definitions/Other"`) {
+		t.Errorf("expected the ref to render in $ref form, got %s", out)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// EnumUniverses maps each enum-bearing field path of a resolved schema to its
+// allowed values, e.g. for generating CEL "in" checks. Paths follow the same
+// convention as resolution error messages: dot-joined property names with
+// "[*]" for array items and map values, and "<root>" for an enum on the root
+// itself. Fields without an enum are absent from the map.
+func EnumUniverses(s *spec.Schema) map[string][]interface{} {
+	universes := map[string][]interface{}{}
+	collectEnums(s, "", universes)
+	return universes
+}
+
+func collectEnums(s *spec.Schema, path string, universes map[string][]interface{}) {
+	if len(s.Enum) > 0 {
+		universes[displayPath(path)] = s.Enum
+	}
+	for name, prop := range s.Properties {
+		collectEnums(&prop, childPath(path, name), universes)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		collectEnums(s.AdditionalProperties.Schema, path+"[*]", universes)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		collectEnums(s.Items.Schema, path+"[*]", universes)
+	}
+}
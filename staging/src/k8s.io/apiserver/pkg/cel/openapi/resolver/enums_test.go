@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestEnumUniverses(t *testing.T) {
+	s := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"protocol": {SchemaProps: spec.SchemaProps{
+							Type: []string{"string"},
+							Enum: []interface{}{"TCP", "UDP", "SCTP"},
+						}},
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+						"conditions": {SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+								Type: []string{"object"},
+								Properties: map[string]spec.Schema{
+									"status": {SchemaProps: spec.SchemaProps{
+										Type: []string{"string"},
+										Enum: []interface{}{"True", "False", "Unknown"},
+									}},
+								},
+							}}},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	universes := EnumUniverses(s)
+	if len(universes) != 2 {
+		t.Fatalf("expected universes at 2 paths, got %v", universes)
+	}
+	if got := universes["spec.protocol"]; !reflect.DeepEqual(got, []interface{}{"TCP", "UDP", "SCTP"}) {
+		t.Errorf("unexpected universe for spec.protocol: %v", got)
+	}
+	if got := universes["spec.conditions[*].status"]; !reflect.DeepEqual(got, []interface{}{"True", "False", "Unknown"}) {
+		t.Errorf("unexpected universe for spec.conditions[*].status: %v", got)
+	}
+	if _, ok := universes["spec.replicas"]; ok {
+		t.Error("expected non-enum fields to be absent")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import "fmt"
+
+// ErrRootNotFound is wrapped and returned by PopulateRefs when schemaOf
+// cannot find the root ref itself, as opposed to a transitive ref, so
+// callers can distinguish a bad input ref from an incomplete bundle. It
+// wraps ErrSchemaNotFound, so existing errors.Is checks keep working.
+var ErrRootNotFound = fmt.Errorf("root %w", ErrSchemaNotFound)
+
+// ErrSchemaTooLarge is wrapped and returned when resolution exceeds the
+// Options.MaxNodes budget, before the expansion can exhaust memory.
+var ErrSchemaTooLarge = fmt.Errorf("schema too large")
+
+// UnresolvedRefError is returned by PopulateRefs when a ref has no
+// corresponding schema. It wraps ErrSchemaNotFound, so existing errors.Is
+// checks keep working.
+type UnresolvedRefError struct {
+	// Ref is the reference that could not be resolved.
+	Ref string
+	// Path is the field path of the referencing node.
+	Path string
+}
+
+func (e *UnresolvedRefError) Error() string {
+	return fmt.Sprintf("internal error: cannot resolve Ref %q at %q: %v", e.Ref, e.Path, ErrSchemaNotFound)
+}
+
+func (e *UnresolvedRefError) Unwrap() error {
+	return ErrSchemaNotFound
+}
+
+// CyclicRefError is returned by PopulateRefs when FailOnCycle is set and a
+// reference cycle is encountered. Without FailOnCycle, cycles resolve to an
+// empty object placeholder instead.
+type CyclicRefError struct {
+	// Ref is the reference that closed the cycle.
+	Ref string
+	// Path is the field path at which the cycle was detected.
+	Path string
+}
+
+func (e *CyclicRefError) Error() string {
+	return fmt.Sprintf("cyclic reference to %q at %q", e.Ref, e.Path)
+}
+
+// LimitExceededError is returned by PopulateRefs when a configured resource
+// limit (e.g. MaxPropertiesPerObject or MaxResolvedBytes) is exceeded.
+type LimitExceededError struct {
+	// Path is the field path at which the limit was exceeded.
+	Path string
+	// Limit is the configured limit and Actual the observed value.
+	Limit, Actual int
+	// Unit describes what was limited, "properties" or "bytes".
+	Unit string
+}
+
+func (e *LimitExceededError) Error() string {
+	switch e.Unit {
+	case "bytes":
+		return fmt.Sprintf("resolved schema exceeds the maximum size of %d bytes (approximately %d bytes at %q)", e.Limit, e.Actual, e.Path)
+	default:
+		return fmt.Sprintf("object at %q has %d properties, exceeding the limit of %d", e.Path, e.Actual, e.Limit)
+	}
+}
+
+// ErrNilResolvedSchema is wrapped and returned when schemaOf reports a ref as
+// found but hands back a nil schema. Failing cleanly here instead of
+// dereferencing the nil keeps a buggy resolver from panicking the caller.
+var ErrNilResolvedSchema = fmt.Errorf("resolved schema is nil")
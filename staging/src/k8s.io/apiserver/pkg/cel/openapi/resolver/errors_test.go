@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestUnresolvedRefErrorAs(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Missing")}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	_, err := PopulateRefs(schemaOf, "#/definitions/Root")
+	if err == nil {
+		t.Fatal("expected error for a missing ref, got none")
+	}
+	unresolved := &UnresolvedRefError{}
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("expected UnresolvedRefError, got %T: %v", err, err)
+	}
+	if unresolved.Ref != "#/definitions/Missing" || unresolved.Path != "spec" {
+		t.Errorf("expected ref and path on the error, got %+v", unresolved)
+	}
+	// the error keeps wrapping ErrSchemaNotFound
+	if !errors.Is(err, ErrSchemaNotFound) {
+		t.Error("expected UnresolvedRefError to wrap ErrSchemaNotFound")
+	}
+	// wrapping preserves the type
+	wrapped := fmt.Errorf("resolving policy param: %w", err)
+	if !errors.As(wrapped, &unresolved) {
+		t.Error("expected errors.As to see through wrapping")
+	}
+}
+
+func TestCyclicRefErrorAs(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Loop": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"next": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Loop")}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	// by default, cycles resolve to a placeholder
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Loop"); err != nil {
+		t.Errorf("unexpected error without FailOnCycle: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Loop", Options{FailOnCycle: true})
+	if err == nil {
+		t.Fatal("expected cyclic ref error, got none")
+	}
+	cyclic := &CyclicRefError{}
+	if !errors.As(err, &cyclic) {
+		t.Fatalf("expected CyclicRefError, got %T: %v", err, err)
+	}
+	if cyclic.Ref != "#/definitions/Loop" || cyclic.Path != "next" {
+		t.Errorf("expected ref and path on the error, got %+v", cyclic)
+	}
+	wrapped := fmt.Errorf("warming cache: %w", err)
+	if !errors.As(wrapped, &cyclic) {
+		t.Error("expected errors.As to see through wrapping")
+	}
+}
+
+func TestLimitExceededErrorAs(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"a": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"b": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MaxPropertiesPerObject: 1})
+	if err == nil {
+		t.Fatal("expected limit error, got none")
+	}
+	limit := &LimitExceededError{}
+	if !errors.As(err, &limit) {
+		t.Fatalf("expected LimitExceededError, got %T: %v", err, err)
+	}
+	if limit.Limit != 1 || limit.Actual != 2 || limit.Unit != "properties" {
+		t.Errorf("expected limit details on the error, got %+v", limit)
+	}
+	wrapped := fmt.Errorf("compiling policy: %w", err)
+	if !errors.As(wrapped, &limit) {
+		t.Error("expected errors.As to see through wrapping")
+	}
+}
+
+func TestErrRootNotFound(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Missing")}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	// a missing root reports ErrRootNotFound (and still ErrSchemaNotFound)
+	_, err := PopulateRefs(schemaOf, "DoesNotExist")
+	if !errors.Is(err, ErrRootNotFound) {
+		t.Errorf("expected ErrRootNotFound for a missing root, got %v", err)
+	}
+	if !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrRootNotFound to wrap ErrSchemaNotFound, got %v", err)
+	}
+
+	// a present root with a missing child reports only the transitive miss
+	_, err = PopulateRefs(schemaOf, "Root")
+	if errors.Is(err, ErrRootNotFound) {
+		t.Errorf("expected a transitive miss not to report ErrRootNotFound, got %v", err)
+	}
+	if !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for the missing child, got %v", err)
+	}
+}
+
+func TestNilResolvedSchema(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Broken")}},
+			},
+		}},
+	}
+	// a brittle schemaOf that claims success but returns no schema
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "Broken" {
+			return nil, true
+		}
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	_, err := PopulateRefs(schemaOf, "Root")
+	if !errors.Is(err, ErrNilResolvedSchema) {
+		t.Errorf("expected ErrNilResolvedSchema for a transitive ref, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "Broken") {
+		t.Errorf("expected the error to name the ref, got %v", err)
+	}
+
+	if _, err := PopulateRefs(func(string) (*spec.Schema, bool) { return nil, true }, "Root"); !errors.Is(err, ErrNilResolvedSchema) {
+		t.Errorf("expected ErrNilResolvedSchema for a nil root, got %v", err)
+	}
+}
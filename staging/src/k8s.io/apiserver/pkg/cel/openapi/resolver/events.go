@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// NewEventsResolver returns a SchemaResolver preloaded with schemas for the
+// Event kinds commonly used in security policies — events.k8s.io/v1 Event and
+// audit.k8s.io/v1 Event — so such policies can be typed regardless of the
+// cluster's discovery state. Equivalent to NewCatalogResolver(CatalogEvents);
+// the same partial-coverage caveats apply.
+func NewEventsResolver() SchemaResolver {
+	return &catalogResolver{schemas: eventSchemas()}
+}
+
+// eventSchemas covers: events.k8s.io Event eventTime, reason,
+// regarding/related object references, note, type, action,
+// reportingController, reportingInstance, and series, besides
+// apiVersion/kind/metadata(name, namespace, labels); audit.k8s.io Event
+// level, auditID, stage, requestURI, verb, user/impersonatedUser, sourceIPs,
+// userAgent, objectRef, responseStatus, the two timestamps, and annotations,
+// besides apiVersion/kind.
+func eventSchemas() map[schema.GroupVersionKind]*spec.Schema {
+	stringType := *spec.StringProperty()
+	int32Type := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Format: "int32"}}
+	timeType := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "date-time"}}
+	stringMap := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		AdditionalProperties: &spec.SchemaOrBool{
+			Allows: true,
+			Schema: spec.StringProperty(),
+		},
+	}}
+	metadata := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name":      stringType,
+			"namespace": stringType,
+			"labels":    stringMap,
+		},
+	}}
+	typeMeta := map[string]spec.Schema{
+		"apiVersion": stringType,
+		"kind":       stringType,
+		"metadata":   metadata,
+	}
+	objectReference := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"apiVersion":      stringType,
+			"kind":            stringType,
+			"name":            stringType,
+			"namespace":       stringType,
+			"uid":             stringType,
+			"resourceVersion": stringType,
+			"fieldPath":       stringType,
+		},
+	}}
+
+	event := objectSchemaWith(typeMeta, map[string]spec.Schema{
+		"eventTime":           timeType,
+		"reason":              stringType,
+		"regarding":           objectReference,
+		"related":             objectReference,
+		"note":                stringType,
+		"type":                stringType,
+		"action":              stringType,
+		"reportingController": stringType,
+		"reportingInstance":   stringType,
+		"series": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"count":            int32Type,
+				"lastObservedTime": timeType,
+			},
+		}},
+	})
+
+	auditEvent := objectSchemaWith(map[string]spec.Schema{
+		"apiVersion": stringType,
+		"kind":       stringType,
+	}, map[string]spec.Schema{
+		"level":      stringType,
+		"auditID":    stringType,
+		"stage":      stringType,
+		"requestURI": stringType,
+		"verb":       stringType,
+		"user": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"username": stringType,
+				"uid":      stringType,
+				"groups": {SchemaProps: spec.SchemaProps{
+					Type:  []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: spec.StringProperty()},
+				}},
+			},
+		}},
+		"impersonatedUser": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"username": stringType,
+				"uid":      stringType,
+				"groups": {SchemaProps: spec.SchemaProps{
+					Type:  []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: spec.StringProperty()},
+				}},
+			},
+		}},
+		"sourceIPs": {SchemaProps: spec.SchemaProps{
+			Type:  []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: spec.StringProperty()},
+		}},
+		"userAgent": stringType,
+		"objectRef": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"resource":        stringType,
+				"namespace":       stringType,
+				"name":            stringType,
+				"uid":             stringType,
+				"apiGroup":        stringType,
+				"apiVersion":      stringType,
+				"resourceVersion": stringType,
+				"subresource":     stringType,
+			},
+		}},
+		"responseStatus": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"status":  stringType,
+				"message": stringType,
+				"reason":  stringType,
+				"code":    int32Type,
+			},
+		}},
+		"requestReceivedTimestamp": timeType,
+		"stageTimestamp":           timeType,
+		"annotations":              stringMap,
+	})
+
+	return map[schema.GroupVersionKind]*spec.Schema{
+		{Group: "events.k8s.io", Version: "v1", Kind: "Event"}: event,
+		{Group: "audit.k8s.io", Version: "v1", Kind: "Event"}:  auditEvent,
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestEventsResolver(t *testing.T) {
+	r := NewEventsResolver()
+
+	event, err := r.ResolveSchema(schema.GroupVersionKind{Group: "events.k8s.io", Version: "v1", Kind: "Event"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !event.Properties["regarding"].Properties["name"].Type.Contains("string") {
+		t.Error("expected regarding.name to be typed as string")
+	}
+	if got := event.Properties["eventTime"].Format; got != "date-time" {
+		t.Errorf("expected eventTime to carry the date-time format, got %q", got)
+	}
+
+	audit, err := r.ResolveSchema(schema.GroupVersionKind{Group: "audit.k8s.io", Version: "v1", Kind: "Event"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !audit.Properties["user"].Properties["username"].Type.Contains("string") {
+		t.Error("expected user.username to be typed as string")
+	}
+	if !audit.Properties["objectRef"].Properties["resource"].Type.Contains("string") {
+		t.Error("expected objectRef.resource to be typed as string")
+	}
+
+	if _, err := r.ResolveSchema(schema.GroupVersionKind{Version: "v1", Kind: "Event"}); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for the core Event, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// ExportResolved resolves each given GVK through the resolver and assembles a
+// fresh, self-contained Swagger document whose definitions are the resolved
+// (ref-free) schemas, keyed by their canonical definition names and carrying
+// the x-kubernetes-group-version-kind extension. The document is portable: it
+// references no definitions outside itself.
+func ExportResolved(r SchemaResolver, gvks []schema.GroupVersionKind) (*spec.Swagger, error) {
+	definitions := make(spec.Definitions, len(gvks))
+	for _, gvk := range gvks {
+		s, err := r.ResolveSchema(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("cannot export %v: %w", gvk, err)
+		}
+		exported := *s
+		ext := make(spec.Extensions, len(exported.Extensions)+1)
+		for k, v := range exported.Extensions {
+			ext[k] = v
+		}
+		ext[extGVK] = []interface{}{map[string]interface{}{
+			"group":   gvk.Group,
+			"version": gvk.Version,
+			"kind":    gvk.Kind,
+		}}
+		exported.Extensions = ext
+		definitions[canonicalDefinitionName(gvk)] = exported
+	}
+	return &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Swagger:     "2.0",
+		Definitions: definitions,
+	}}, nil
+}
+
+// canonicalDefinitionName derives a definition name from a GVK by reversing
+// the group's DNS segments, e.g. example.com/v1 Widget becomes
+// com.example.v1.Widget. The empty (core) group maps to "core".
+func canonicalDefinitionName(gvk schema.GroupVersionKind) string {
+	group := "core"
+	if len(gvk.Group) > 0 {
+		segments := strings.Split(gvk.Group, ".")
+		for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+			segments[i], segments[j] = segments[j], segments[i]
+		}
+		group = strings.Join(segments, ".")
+	}
+	return strings.Join([]string{group, gvk.Version, gvk.Kind}, ".")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestExportResolved(t *testing.T) {
+	widgetGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	delegate := mapResolver{
+		widgetGVK: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+		}},
+		podGVK: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"metadata": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+		}},
+	}
+
+	doc, err := ExportResolved(delegate, []schema.GroupVersionKind{widgetGVK, podGVK})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(doc.Definitions) != 2 {
+		t.Fatalf("expected 2 definitions, got %d", len(doc.Definitions))
+	}
+	widget, ok := doc.Definitions["com.example.v1.Widget"]
+	if !ok {
+		t.Fatalf("expected canonical name com.example.v1.Widget, got %v", doc.Definitions)
+	}
+	gvks, ok := widget.Extensions[extGVK].([]interface{})
+	if !ok || len(gvks) != 1 {
+		t.Errorf("expected the GVK extension to be set, got %v", widget.Extensions[extGVK])
+	}
+	if _, ok := doc.Definitions["core.v1.Pod"]; !ok {
+		t.Errorf("expected the core group to map to core.v1.Pod, got %v", doc.Definitions)
+	}
+
+	// the document must be self-contained: no refs anywhere
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(b), `"$ref"`) {
+		t.Errorf("expected the exported document to be ref-free, got %s", string(b))
+	}
+}
+
+func TestExportResolvedUnknownGVK(t *testing.T) {
+	_, err := ExportResolved(mapResolver{}, []schema.GroupVersionKind{
+		{Group: "example.com", Version: "v1", Kind: "Widget"},
+	})
+	if !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for an unknown GVK, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// DocumentLoader fetches and parses an external OpenAPI document, returning
+// its schemas keyed by name. The document string is the portion of the ref
+// before "#", e.g. "common.yaml" in "common.yaml#/components/schemas/Money".
+type DocumentLoader func(document string) (map[string]*spec.Schema, error)
+
+// SchemaOfWithExternalRefs wraps schemaOf so that refs carrying a document
+// portion, e.g. "common.yaml#/components/schemas/Money", are resolved by
+// loading that document through the given loader. Loaded documents are cached
+// by document string, so many refs into the same document fetch it once; load
+// failures are not cached. Refs without a document portion, including plain
+// "#/"-refs, go to the wrapped schemaOf unchanged.
+//
+// Cycles between external documents are handled by PopulateRefs' usual cycle
+// detection, which keys on the full ref string. Refs inside an external
+// document must themselves carry their document portion (or be resolvable by
+// the wrapped schemaOf); a bare "#/"-ref in an external document would be
+// resolved against the root document.
+func SchemaOfWithExternalRefs(schemaOf func(ref string) (*spec.Schema, bool), load DocumentLoader) func(ref string) (*spec.Schema, bool) {
+	var mu sync.Mutex
+	docs := make(map[string]map[string]*spec.Schema)
+	return func(ref string) (*spec.Schema, bool) {
+		sep := strings.Index(ref, "#")
+		if sep <= 0 {
+			// an internal ref, or a plain definition name
+			return schemaOf(ref)
+		}
+		document, fragment := ref[:sep], ref[sep+1:]
+		mu.Lock()
+		schemas, ok := docs[document]
+		if !ok {
+			var err error
+			schemas, err = load(document)
+			if err != nil {
+				mu.Unlock()
+				return nil, false
+			}
+			docs[document] = schemas
+		}
+		mu.Unlock()
+		name := strings.TrimPrefix(fragment, "/components/schemas/")
+		name = strings.TrimPrefix(name, "/definitions/")
+		s, ok := schemas[name]
+		return s, ok
+	}
+}
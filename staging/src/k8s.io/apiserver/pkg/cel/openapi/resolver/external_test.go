@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestSchemaOfWithExternalRefs(t *testing.T) {
+	internal := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"price":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("common.yaml#/components/schemas/Money")}},
+					"refund": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("common.yaml#/components/schemas/Money")}},
+					"spec":   {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+				},
+			},
+		},
+		"Spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	loads := 0
+	load := func(document string) (map[string]*spec.Schema, error) {
+		loads++
+		if document != "common.yaml" {
+			return nil, fmt.Errorf("unknown document %q", document)
+		}
+		return map[string]*spec.Schema{
+			"Money": {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "decimal"}},
+		}, nil
+	}
+	schemaOf := SchemaOfWithExternalRefs(func(ref string) (*spec.Schema, bool) {
+		s, ok := internal[ref]
+		return s, ok
+	}, load)
+
+	resolved, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	price := resolved.Properties["price"]
+	if price.Format != "decimal" {
+		t.Errorf("expected the external schema to be resolved, got %v", price)
+	}
+	specProp := resolved.Properties["spec"]
+	if len(specProp.Type) != 1 || specProp.Type[0] != "object" {
+		t.Errorf("expected internal refs to keep working, got %v", specProp)
+	}
+	if loads != 1 {
+		t.Errorf("expected the document to be loaded once, got %d loads", loads)
+	}
+}
+
+func TestSchemaOfWithExternalRefsCycle(t *testing.T) {
+	docs := map[string]map[string]*spec.Schema{
+		"a.yaml": {
+			"A": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"b": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("b.yaml#/components/schemas/B")}},
+				},
+			}},
+		},
+		"b.yaml": {
+			"B": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"a": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("a.yaml#/components/schemas/A")}},
+				},
+			}},
+		},
+	}
+	schemaOf := SchemaOfWithExternalRefs(func(ref string) (*spec.Schema, bool) {
+		return nil, false
+	}, func(document string) (map[string]*spec.Schema, error) {
+		schemas, ok := docs[document]
+		if !ok {
+			return nil, fmt.Errorf("unknown document %q", document)
+		}
+		return schemas, nil
+	})
+
+	resolved, err := PopulateRefs(schemaOf, "a.yaml#/components/schemas/A")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the cycle resolves to the usual empty-object placeholder instead of
+	// looping forever
+	inner := resolved.Properties["b"].Properties["a"]
+	if len(inner.Properties) != 0 || !inner.Type.Contains("object") {
+		t.Errorf("expected the cycle to collapse to the placeholder, got %v", inner)
+	}
+}
+
+func TestSchemaOfWithExternalRefsLoadFailure(t *testing.T) {
+	loads := 0
+	schemaOf := SchemaOfWithExternalRefs(func(ref string) (*spec.Schema, bool) {
+		return nil, false
+	}, func(document string) (map[string]*spec.Schema, error) {
+		loads++
+		return nil, fmt.Errorf("unreachable")
+	})
+	if _, ok := schemaOf("common.yaml#/components/schemas/Money"); ok {
+		t.Error("expected a load failure to report not found")
+	}
+	// failures are not cached; the next lookup retries
+	schemaOf("common.yaml#/components/schemas/Money")
+	if loads != 2 {
+		t.Errorf("expected 2 load attempts, got %d", loads)
+	}
+}
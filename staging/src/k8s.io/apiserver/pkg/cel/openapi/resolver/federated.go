@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// FederatedResolver resolves schemas against the member clusters of a
+// federation, each with its own SchemaResolver, so that policies can be
+// validated against every cluster that will serve them.
+type FederatedResolver struct {
+	clusters map[string]SchemaResolver
+}
+
+// NewFederatedResolver returns a FederatedResolver over the given clusters,
+// keyed by cluster name.
+func NewFederatedResolver(clusters map[string]SchemaResolver) *FederatedResolver {
+	return &FederatedResolver{clusters: clusters}
+}
+
+// ResolveSchemaInCluster resolves the schema of gvk as served by the named
+// cluster.
+func (r *FederatedResolver) ResolveSchemaInCluster(cluster string, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	delegate, ok := r.clusters[cluster]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q", cluster)
+	}
+	return delegate.ResolveSchema(gvk)
+}
+
+// ResolveSchemaConsistent resolves the schema of gvk in every cluster and
+// requires all clusters to agree on it, catching schema skew across the
+// federation. The divergence error names the clusters whose schemas differ
+// from the rest.
+func (r *FederatedResolver) ResolveSchemaConsistent(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	var reference *spec.Schema
+	var referenceHash [32]byte
+	var referenceCluster string
+	divergent := sets.New[string]()
+	for _, cluster := range sets.List(sets.KeySet(r.clusters)) {
+		s, err := r.clusters[cluster].ResolveSchema(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve %v in cluster %q: %w", gvk, cluster, err)
+		}
+		hash, err := SchemaHash(s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot hash schema of %v from cluster %q: %w", gvk, cluster, err)
+		}
+		if reference == nil {
+			reference, referenceHash, referenceCluster = s, hash, cluster
+			continue
+		}
+		if hash != referenceHash {
+			divergent.Insert(cluster)
+		}
+	}
+	if reference == nil {
+		return nil, fmt.Errorf("cannot resolve %v: no clusters configured", gvk)
+	}
+	if divergent.Len() > 0 {
+		return nil, fmt.Errorf("schema of %v diverges across the federation: clusters %s differ from %q",
+			gvk, strings.Join(sets.List(divergent), ", "), referenceCluster)
+	}
+	return reference, nil
+}
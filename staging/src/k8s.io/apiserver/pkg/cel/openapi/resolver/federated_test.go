@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestFederatedResolver(t *testing.T) {
+	matching := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	diverging := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+	widget := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		},
+	}}
+	r := NewFederatedResolver(map[string]SchemaResolver{
+		"east": mapResolver{
+			matching:  widget,
+			diverging: {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		},
+		"west": mapResolver{
+			matching: widget,
+			// west serves an extra field for the diverging kind
+			diverging: {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"extra": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			}},
+		},
+	})
+
+	if _, err := r.ResolveSchemaInCluster("east", matching); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := r.ResolveSchemaInCluster("north", matching); err == nil {
+		t.Error("expected error for an unknown cluster, got none")
+	}
+
+	if _, err := r.ResolveSchemaConsistent(matching); err != nil {
+		t.Errorf("unexpected error for a consistent schema: %v", err)
+	}
+	_, err := r.ResolveSchemaConsistent(diverging)
+	if err == nil {
+		t.Fatal("expected divergence error, got none")
+	}
+	if !strings.Contains(err.Error(), "west") || !strings.Contains(err.Error(), `"east"`) {
+		t.Errorf("expected the error to name the diverging clusters, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// PathNotFoundError is returned by ResolveFieldSchema when the requested
+// field path does not exist in the resolved schema.
+type PathNotFoundError struct {
+	// Path is the full requested field path.
+	Path string
+	// Segment is the first path segment that could not be navigated.
+	Segment string
+}
+
+func (e *PathNotFoundError) Error() string {
+	return fmt.Sprintf("field path %q not found: cannot navigate %q", e.Path, e.Segment)
+}
+
+// ResolveFieldSchema resolves the schema identified by gvk and navigates to
+// the sub-schema at the given field path. The path is a dotted sequence of
+// property names where "[]" descends into array items, for example
+// "spec.containers[].name". The returned sub-schema is fully resolved because
+// navigation happens after resolution.
+func ResolveFieldSchema(r SchemaResolver, gvk schema.GroupVersionKind, fieldPath string) (*spec.Schema, error) {
+	s, err := r.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	current := s
+	for _, segment := range splitFieldPath(fieldPath) {
+		if segment == "[]" {
+			if current.Items == nil || current.Items.Schema == nil {
+				return nil, &PathNotFoundError{Path: fieldPath, Segment: segment}
+			}
+			current = current.Items.Schema
+			continue
+		}
+		prop, ok := current.Properties[segment]
+		if !ok {
+			return nil, &PathNotFoundError{Path: fieldPath, Segment: segment}
+		}
+		current = &prop
+	}
+	return current, nil
+}
+
+// splitFieldPath splits a field path into property name and "[]" segments.
+// "spec.containers[].name" becomes ["spec", "containers", "[]", "name"].
+func splitFieldPath(fieldPath string) []string {
+	var segments []string
+	for _, part := range strings.Split(fieldPath, ".") {
+		if len(part) == 0 {
+			continue
+		}
+		descents := 0
+		for strings.HasSuffix(part, "[]") {
+			part = strings.TrimSuffix(part, "[]")
+			descents++
+		}
+		if len(part) > 0 {
+			segments = append(segments, part)
+		}
+		for i := 0; i < descents; i++ {
+			segments = append(segments, "[]")
+		}
+	}
+	return segments
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func fieldPathTestResolver() SchemaResolver {
+	return &fakeResolver{schema: &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"containers": {SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+								SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+									Properties: map[string]spec.Schema{
+										"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+									},
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}}
+}
+
+func TestResolveFieldSchema(t *testing.T) {
+	r := fieldPathTestResolver()
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+	tests := []struct {
+		path     string
+		wantType string
+	}{
+		{path: "spec", wantType: "object"},
+		{path: "spec.containers", wantType: "array"},
+		{path: "spec.containers[]", wantType: "object"},
+		{path: "spec.containers[].name", wantType: "string"},
+		{path: "", wantType: "object"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			s, err := ResolveFieldSchema(r, gvk, tc.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !s.Type.Contains(tc.wantType) {
+				t.Errorf("expected type %q at %q, got %v", tc.wantType, tc.path, s.Type)
+			}
+		})
+	}
+}
+
+func TestResolveFieldSchemaNotFound(t *testing.T) {
+	r := fieldPathTestResolver()
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+	for _, path := range []string{"status", "spec.volumes", "spec.containers[].image", "spec[]"} {
+		_, err := ResolveFieldSchema(r, gvk, path)
+		if err == nil {
+			t.Errorf("expected error for path %q, got none", path)
+			continue
+		}
+		pathErr := &PathNotFoundError{}
+		if !errors.As(err, &pathErr) {
+			t.Errorf("expected PathNotFoundError for path %q, got %T: %v", path, err, err)
+			continue
+		}
+		if pathErr.Path != path {
+			t.Errorf("expected error to carry path %q, got %q", path, pathErr.Path)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// SchemaHash returns the SHA-256 hash of the schema's JSON serialization.
+// The serialization is deterministic, so equal schemas hash identically.
+func SchemaHash(s *spec.Schema) ([sha256.Size]byte, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
+
+// Fingerprint resolves the schema identified by gvk using the given resolver
+// and returns a short fingerprint: the hex encoding of the first 8 bytes of
+// its SchemaHash. Fingerprints are stable for unchanged schemas, making them
+// suitable for detecting schema drift without shipping whole schemas.
+func Fingerprint(r SchemaResolver, gvk schema.GroupVersionKind) (string, error) {
+	s, err := r.ResolveSchema(gvk)
+	if err != nil {
+		return "", err
+	}
+	h, err := SchemaHash(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h[:8]), nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestFingerprintStability(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	makeSchema := func() *spec.Schema {
+		return &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					"name":     {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			},
+		}
+	}
+
+	first, err := Fingerprint(&fakeResolver{schema: makeSchema()}, gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first) != 16 {
+		t.Errorf("expected a 8-byte (16 hex chars) fingerprint, got %q", first)
+	}
+	second, err := Fingerprint(&fakeResolver{schema: makeSchema()}, gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("expected stable fingerprints for unchanged schemas, got %q and %q", first, second)
+	}
+
+	// an edit must change the fingerprint
+	edited := makeSchema()
+	edited.Properties["replicas"] = spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	third, err := Fingerprint(&fakeResolver{schema: edited}, gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == third {
+		t.Error("expected the fingerprint to change after a schema edit")
+	}
+}
+
+func TestFingerprintResolutionError(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	if _, err := Fingerprint(&fakeResolver{err: ErrSchemaNotFound}, gvk); err == nil {
+		t.Error("expected resolution error to propagate, got none")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// FlattenAllOf merges every allOf in the resolved schema into its parent, so
+// that CEL type construction, which does not understand allOf, sees the
+// complete set of fields. Member properties are unioned into the parent,
+// required lists are combined, and a member's type is adopted when the parent
+// declares none. Conflicting type declarations, whether between the parent
+// and a member or between two members contributing the same property, are an
+// error. anyOf and oneOf are left untouched.
+//
+// The input is not mutated: a copy is returned if anything changed, otherwise
+// the original schema.
+func FlattenAllOf(s *spec.Schema) (*spec.Schema, error) {
+	return flattenAllOf(s, "")
+}
+
+func flattenAllOf(s *spec.Schema, path string) (*spec.Schema, error) {
+	result := *s
+	changed := false
+	if len(result.Properties) > 0 {
+		props := make(map[string]spec.Schema, len(result.Properties))
+		propsChanged := false
+		for name, prop := range result.Properties {
+			child, err := flattenAllOf(&prop, childPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			if child != &prop {
+				propsChanged = true
+			}
+			props[name] = *child
+		}
+		if propsChanged {
+			changed = true
+			result.Properties = props
+		}
+	}
+	if result.Items != nil && result.Items.Schema != nil {
+		child, err := flattenAllOf(result.Items.Schema, path+"[*]")
+		if err != nil {
+			return nil, err
+		}
+		if child != result.Items.Schema {
+			changed = true
+			newItems := *result.Items
+			newItems.Schema = child
+			result.Items = &newItems
+		}
+	}
+	if result.AdditionalProperties != nil && result.AdditionalProperties.Schema != nil {
+		child, err := flattenAllOf(result.AdditionalProperties.Schema, path+"[*]")
+		if err != nil {
+			return nil, err
+		}
+		if child != result.AdditionalProperties.Schema {
+			changed = true
+			newProps := *result.AdditionalProperties
+			newProps.Schema = child
+			result.AdditionalProperties = &newProps
+		}
+	}
+	if len(result.AllOf) > 0 {
+		props := make(map[string]spec.Schema, len(result.Properties))
+		for name, prop := range result.Properties {
+			props[name] = prop
+		}
+		required := make([]string, len(result.Required))
+		copy(required, result.Required)
+		seen := sets.New(required...)
+		for _, member := range result.AllOf {
+			flat, err := flattenAllOf(&member, path)
+			if err != nil {
+				return nil, err
+			}
+			if len(flat.Type) > 0 {
+				switch {
+				case len(result.Type) == 0:
+					result.Type = flat.Type
+				case strings.Join(result.Type, "|") != strings.Join(flat.Type, "|"):
+					return nil, fmt.Errorf("conflicting types %q and %q in allOf at %s",
+						strings.Join(result.Type, "|"), strings.Join(flat.Type, "|"), displayPath(path))
+				}
+			}
+			for name, prop := range flat.Properties {
+				if existing, ok := props[name]; ok {
+					if strings.Join(existing.Type, "|") != strings.Join(prop.Type, "|") {
+						return nil, fmt.Errorf("conflicting types %q and %q in allOf at %s",
+							strings.Join(existing.Type, "|"), strings.Join(prop.Type, "|"), childPath(path, name))
+					}
+					// the first declaration wins for compatible duplicates.
+					continue
+				}
+				props[name] = prop
+			}
+			for _, name := range flat.Required {
+				if !seen.Has(name) {
+					seen.Insert(name)
+					required = append(required, name)
+				}
+			}
+		}
+		if len(props) > 0 {
+			result.Properties = props
+		}
+		if len(required) > 0 {
+			result.Required = required
+		}
+		result.AllOf = nil
+		changed = true
+	}
+	if changed {
+		return &result, nil
+	}
+	return s, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestFlattenAllOf(t *testing.T) {
+	original := &spec.Schema{SchemaProps: spec.SchemaProps{
+		AllOf: []spec.Schema{
+			{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+				Required: []string{"name"},
+			}},
+			{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+				},
+				Required: []string{"replicas"},
+			}},
+		},
+	}}
+
+	flat, err := FlattenAllOf(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(flat.AllOf) != 0 {
+		t.Errorf("expected allOf to be merged away, got %v", flat.AllOf)
+	}
+	if len(flat.Type) != 1 || flat.Type[0] != "object" {
+		t.Errorf("expected the member type to be adopted, got %v", flat.Type)
+	}
+	if len(flat.Properties) != 2 {
+		t.Errorf("expected properties from both members, got %v", flat.Properties)
+	}
+	if len(flat.Required) != 2 {
+		t.Errorf("expected required from both members, got %v", flat.Required)
+	}
+	if len(original.AllOf) != 2 {
+		t.Error("expected the original schema to be unchanged")
+	}
+}
+
+func TestFlattenAllOfConflictingTypes(t *testing.T) {
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{
+				AllOf: []spec.Schema{
+					{SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+					{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			}},
+		},
+	}}
+	_, err := FlattenAllOf(s)
+	if err == nil {
+		t.Fatal("expected an error for conflicting types")
+	}
+	if !strings.Contains(err.Error(), "spec") {
+		t.Errorf("expected the error to report the path, got %v", err)
+	}
+}
+
+func TestFlattenAllOfNoChangeReturnsOriginal(t *testing.T) {
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		},
+		AnyOf: []spec.Schema{
+			{SchemaProps: spec.SchemaProps{Required: []string{"name"}}},
+		},
+	}}
+	flat, err := FlattenAllOf(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flat != s {
+		t.Error("expected the original schema back when nothing changed")
+	}
+	if len(flat.AnyOf) != 1 {
+		t.Errorf("expected anyOf to be left untouched, got %v", flat.AnyOf)
+	}
+}
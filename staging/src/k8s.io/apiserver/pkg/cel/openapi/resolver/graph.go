@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// RefGraph is the ref dependency graph reachable from a root definition,
+// built without expanding any schemas. It answers "what does this type
+// transitively depend on" before committing to a full PopulateRefs.
+type RefGraph struct {
+	// Root is the ref the graph was built from.
+	Root string
+	// Nodes lists every ref reachable from Root, in sorted order.
+	Nodes []string
+	// Edges maps each ref to the refs its schema mentions directly, in
+	// sorted order.
+	Edges map[string][]string
+}
+
+// RefDependencies walks the schema of root and of every transitively
+// referenced definition — through properties, items, additionalProperties,
+// and combinators — and returns the resulting dependency graph. A ref that
+// schemaOf cannot find is an error wrapping ErrSchemaNotFound, which makes
+// this a cheap completeness check for a schema bundle.
+func RefDependencies(schemaOf func(ref string) (*spec.Schema, bool), root string) (*RefGraph, error) {
+	g := &RefGraph{Root: root, Edges: make(map[string][]string)}
+	visited := sets.New[string]()
+	queue := []string{root}
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if visited.Has(ref) {
+			continue
+		}
+		visited.Insert(ref)
+		s, ok := schemaOf(ref)
+		if !ok {
+			return nil, fmt.Errorf("cannot resolve Ref %q: %w", ref, ErrSchemaNotFound)
+		}
+		deps := directRefs(s)
+		g.Edges[ref] = deps
+		queue = append(queue, deps...)
+	}
+	g.Nodes = sets.List(visited)
+	return g, nil
+}
+
+// directRefs returns the refs the schema mentions directly, in sorted order.
+func directRefs(s *spec.Schema) []string {
+	found := sets.New[string]()
+	// the visitor never fails, so neither does the walk
+	_ = WalkSchema(s, func(path string, node *spec.Schema) error {
+		if node.Ref.GetURL() != nil {
+			found.Insert(node.Ref.String())
+		}
+		return nil
+	})
+	return sets.List(found)
+}
+
+// TopoOrder returns the graph's refs ordered so that every ref appears after
+// the refs it depends on, e.g. for loading definitions bottom-up. An error
+// is returned when the graph contains a cycle.
+func (g *RefGraph) TopoOrder() ([]string, error) {
+	remaining := sets.New(g.Nodes...)
+	done := sets.New[string]()
+	order := make([]string, 0, len(g.Nodes))
+	for remaining.Len() > 0 {
+		progressed := false
+		for _, ref := range sets.List(remaining) {
+			ready := true
+			for _, dep := range g.Edges[ref] {
+				if !done.Has(dep) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				order = append(order, ref)
+				done.Insert(ref)
+				remaining.Delete(ref)
+				progressed = true
+			}
+		}
+		if !progressed {
+			return nil, fmt.Errorf("ref graph contains a cycle among %v", sets.List(remaining))
+		}
+	}
+	return order, nil
+}
+
+// Cycles returns the reference cycles in the graph, one sorted ref list per
+// strongly connected component of more than one ref or with a self
+// reference. An empty result means the bundle is safe to expand without
+// hitting cycle placeholders.
+func (g *RefGraph) Cycles() [][]string {
+	index := make(map[string]int, len(g.Nodes))
+	lowlink := make(map[string]int, len(g.Nodes))
+	onStack := sets.New[string]()
+	var stack []string
+	next := 0
+	var cycles [][]string
+
+	var strongConnect func(ref string)
+	strongConnect = func(ref string) {
+		index[ref] = next
+		lowlink[ref] = next
+		next++
+		stack = append(stack, ref)
+		onStack.Insert(ref)
+		for _, dep := range g.Edges[ref] {
+			if _, seen := index[dep]; !seen {
+				strongConnect(dep)
+				if lowlink[dep] < lowlink[ref] {
+					lowlink[ref] = lowlink[dep]
+				}
+			} else if onStack.Has(dep) && index[dep] < lowlink[ref] {
+				lowlink[ref] = index[dep]
+			}
+		}
+		if lowlink[ref] == index[ref] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack.Delete(top)
+				component = append(component, top)
+				if top == ref {
+					break
+				}
+			}
+			selfLoop := false
+			for _, dep := range g.Edges[ref] {
+				if dep == ref {
+					selfLoop = true
+				}
+			}
+			if len(component) > 1 || selfLoop {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+	for _, ref := range g.Nodes {
+		if _, seen := index[ref]; !seen {
+			strongConnect(ref)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func refTo(ref string) spec.Schema {
+	return spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef(ref)}}
+}
+
+func TestRefDependenciesDiamond(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{"a": refTo("A"), "b": refTo("B")},
+		}},
+		"A": {SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{"c": refTo("C")},
+		}},
+		"B": {SchemaProps: spec.SchemaProps{
+			Type:  []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("C")}}},
+		}},
+		"C": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	g, err := RefDependencies(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(g.Nodes, []string{"A", "B", "C", "Root"}) {
+		t.Errorf("expected all four refs as nodes, got %v", g.Nodes)
+	}
+	if !reflect.DeepEqual(g.Edges["Root"], []string{"A", "B"}) {
+		t.Errorf("expected Root to depend on A and B, got %v", g.Edges["Root"])
+	}
+
+	order, err := g.TopoOrder()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	position := make(map[string]int, len(order))
+	for i, ref := range order {
+		position[ref] = i
+	}
+	if position["C"] > position["A"] || position["C"] > position["B"] || position["Root"] != len(order)-1 {
+		t.Errorf("expected dependencies before dependents, got %v", order)
+	}
+	if cycles := g.Cycles(); len(cycles) != 0 {
+		t.Errorf("expected no cycles in a diamond, got %v", cycles)
+	}
+}
+
+func TestRefDependenciesCycle(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"A": {SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{"b": refTo("B")},
+		}},
+		"B": {SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{"a": refTo("A")},
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	g, err := RefDependencies(schemaOf, "A")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cycles := g.Cycles()
+	if len(cycles) != 1 || !reflect.DeepEqual(cycles[0], []string{"A", "B"}) {
+		t.Errorf("expected the A/B cycle, got %v", cycles)
+	}
+	if _, err := g.TopoOrder(); err == nil {
+		t.Error("expected TopoOrder to fail on a cyclic graph")
+	}
+}
+
+func TestRefDependenciesMissingRef(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{"a": refTo("Missing")},
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	if _, err := RefDependencies(schemaOf, "Root"); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound for the missing ref, got %v", err)
+	}
+}
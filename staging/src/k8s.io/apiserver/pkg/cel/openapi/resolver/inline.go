@@ -0,0 +1,32 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// InlineAll fully expands every ref reachable from rootRef, including those
+// inside combinators, and returns a schema guaranteed to carry no $ref, as
+// required by downstream consumers that feed the output to standard JSON
+// Schema tooling. The x-kubernetes-* extensions are retained verbatim.
+// Unlike PopulateRefs, which substitutes an empty-object placeholder for a
+// cyclic ref, InlineAll fails with a CyclicRefError naming the offending ref
+// and its path, since a flat schema cannot represent a cycle.
+func InlineAll(schemaOf func(ref string) (*spec.Schema, bool), rootRef string) (*spec.Schema, error) {
+	return PopulateRefsWithOptions(schemaOf, rootRef, Options{FailOnCycle: true})
+}
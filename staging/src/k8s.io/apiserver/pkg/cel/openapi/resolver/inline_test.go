@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestInlineAllDAG(t *testing.T) {
+	// a diamond: both branches refer to the same leaf.
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"left":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Left")}},
+				"right": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Right")}},
+			},
+			AllOf: []spec.Schema{
+				{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Mixin")}},
+			},
+		}},
+		"Left":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Leaf")}},
+		"Right": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Leaf")}},
+		"Leaf":  {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		"Mixin": {SchemaProps: spec.SchemaProps{Required: []string{"left"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	resolved, err := InlineAll(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count := countRefs(resolved); count != 0 {
+		t.Errorf("expected no refs to remain, found %d", count)
+	}
+	if refs := CollapsedRefs(resolved); len(refs) != 0 {
+		t.Errorf("expected no refs anywhere, including combinators, got %v", refs)
+	}
+}
+
+func TestInlineAllCycle(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Node": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"next": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Node")}},
+			},
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	var cycleErr *CyclicRefError
+	_, err := InlineAll(schemaOf, "Node")
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected a CyclicRefError, got %v", err)
+	}
+	if cycleErr.Ref != "Node" {
+		t.Errorf("expected the cycle to name the ref, got %q", cycleErr.Ref)
+	}
+	if cycleErr.Path == "" {
+		t.Error("expected the cycle to report its path")
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/common"
+	"k8s.io/apiserver/pkg/cel/openapi"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// LazyDeclTypeResolver converts schemas into CEL DeclTypes on demand. The top
+// type of a kind is built eagerly from its unexpanded schema, while referenced
+// subtypes are expanded and converted only when a field path that crosses them
+// is actually requested, and memoized afterwards. A CEL expression touching
+// only metadata.name therefore never pays for expanding the rest of a large
+// type such as Pod.
+type LazyDeclTypeResolver struct {
+	delegate SchemaResolver
+	schemaOf func(ref string) (*spec.Schema, bool)
+
+	mu    sync.Mutex
+	roots map[schema.GroupVersionKind]*spec.Schema
+	types map[string]*apiservercel.DeclType
+}
+
+// NewLazyDeclTypeResolver returns a LazyDeclTypeResolver resolving schemas
+// via the given resolver.
+func NewLazyDeclTypeResolver(delegate SchemaResolver) *LazyDeclTypeResolver {
+	return &LazyDeclTypeResolver{
+		delegate: delegate,
+		schemaOf: SchemaOfFromResolver(delegate),
+		roots:    map[schema.GroupVersionKind]*spec.Schema{},
+		types:    map[string]*apiservercel.DeclType{},
+	}
+}
+
+// DeclTypeFor returns the top-level DeclType for gvk without expanding any
+// refs. Fields whose schemas are refs surface as dyn until FieldDeclType
+// expands them.
+func (r *LazyDeclTypeResolver) DeclTypeFor(gvk schema.GroupVersionKind) (*apiservercel.DeclType, error) {
+	root, err := r.rootSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	declType := common.SchemaDeclType(&openapi.Schema{Schema: root}, true)
+	if declType == nil {
+		return nil, fmt.Errorf("cannot convert schema of %v to a CEL type", gvk)
+	}
+	return declType.MaybeAssignTypeName(gvk.Kind), nil
+}
+
+// FieldDeclType returns the DeclType of the field reached by following the
+// given property names from the root of gvk. Only the refs along the path and
+// the final field's subtree are expanded; expanded subtrees are memoized by
+// ref so repeated accesses share work.
+func (r *LazyDeclTypeResolver) FieldDeclType(gvk schema.GroupVersionKind, fields ...string) (*apiservercel.DeclType, error) {
+	node, err := r.rootSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	walked := "<root>"
+	for _, name := range fields {
+		node, err = r.resolveNode(node, walked)
+		if err != nil {
+			return nil, err
+		}
+		prop, ok := node.Properties[name]
+		if !ok {
+			return nil, fmt.Errorf("no field %q under %s of %v", name, walked, gvk)
+		}
+		node = &prop
+		walked = walked + "." + name
+	}
+	return r.declTypeOf(node, walked)
+}
+
+// rootSchema resolves and caches the unexpanded root schema of gvk.
+func (r *LazyDeclTypeResolver) rootSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	r.mu.Lock()
+	root, ok := r.roots[gvk]
+	r.mu.Unlock()
+	if ok {
+		return root, nil
+	}
+	root, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roots[gvk] = root
+	return root, nil
+}
+
+// resolveNode substitutes the referred schema for node while it carries a ref,
+// following alias chains but refusing to loop on a cycle.
+func (r *LazyDeclTypeResolver) resolveNode(node *spec.Schema, path string) (*spec.Schema, error) {
+	visited := sets.New[string]()
+	for {
+		ref, isRef := refOf(node)
+		if !isRef {
+			return node, nil
+		}
+		if visited.Has(ref) {
+			return nil, fmt.Errorf("cyclic ref %q at %s", ref, path)
+		}
+		visited.Insert(ref)
+		resolved, ok := r.schemaOf(ref)
+		if !ok {
+			return nil, &UnresolvedRefError{Ref: ref, Path: path}
+		}
+		node = resolved
+	}
+}
+
+// declTypeOf converts node to a DeclType, fully expanding its subtree first.
+// Nodes that are refs are memoized by ref; cycles inside the subtree collapse
+// to the empty-object placeholder as in PopulateRefs.
+func (r *LazyDeclTypeResolver) declTypeOf(node *spec.Schema, path string) (*apiservercel.DeclType, error) {
+	ref, isRef := refOf(node)
+	if isRef {
+		r.mu.Lock()
+		declType, ok := r.types[ref]
+		r.mu.Unlock()
+		if ok {
+			return declType, nil
+		}
+	}
+	expanded, err := PopulateRefsInSchema(r.schemaOf, node)
+	if err != nil {
+		return nil, err
+	}
+	declType := common.SchemaDeclType(&openapi.Schema{Schema: expanded}, false)
+	if declType == nil {
+		return nil, fmt.Errorf("cannot convert schema at %s to a CEL type", path)
+	}
+	if isRef {
+		r.mu.Lock()
+		r.types[ref] = declType
+		r.mu.Unlock()
+	}
+	return declType, nil
+}
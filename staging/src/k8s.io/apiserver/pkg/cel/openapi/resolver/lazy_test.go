@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/common"
+	"k8s.io/apiserver/pkg/cel/openapi"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// lazyTestResolver returns the GVKs and resolver for a Widget whose metadata
+// and spec are referenced subtypes; spec is wide to make eager expansion
+// expensive.
+func lazyTestResolver(specWidth int) (schema.GroupVersionKind, SchemaResolver) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	specProps := make(map[string]spec.Schema, specWidth)
+	for i := 0; i < specWidth; i++ {
+		specProps[fmt.Sprintf("field%03d", i)] = spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	}
+	return gvk, mapResolver{
+		gvk: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"metadata": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("example.com.v1.Meta")}},
+				"spec":     {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("example.com.v1.WidgetSpec")}},
+			},
+		}},
+		{Group: "example.com", Version: "v1", Kind: "Meta"}: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		}},
+		{Group: "example.com", Version: "v1", Kind: "WidgetSpec"}: {SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: specProps,
+		}},
+	}
+}
+
+func TestLazyDeclTypeResolver(t *testing.T) {
+	gvk, delegate := lazyTestResolver(4)
+	r := NewLazyDeclTypeResolver(delegate)
+
+	top, err := r.DeclTypeFor(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := top.Fields["metadata"]; !ok {
+		t.Fatal("expected a metadata field on the top DeclType")
+	}
+
+	name, err := r.FieldDeclType(gvk, "metadata", "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name.TypeName() != "string" {
+		t.Errorf("expected metadata.name to be a string, got %s", name.TypeName())
+	}
+
+	if _, err := r.FieldDeclType(gvk, "metadata", "missing"); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestLazyDeclTypeResolverMemoizes(t *testing.T) {
+	gvk, delegate := lazyTestResolver(4)
+	counting := &lazyCountingResolver{delegate: delegate}
+	r := NewLazyDeclTypeResolver(counting)
+
+	if _, err := r.FieldDeclType(gvk, "spec"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := counting.calls
+	if _, err := r.FieldDeclType(gvk, "spec"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counting.calls != calls {
+		t.Errorf("expected the second access to be served from cache, got %d extra resolves", counting.calls-calls)
+	}
+}
+
+func TestLazyDeclTypeResolverSelfReference(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Node"}
+	delegate := mapResolver{
+		gvk: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"next": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("example.com.v1.Node")}},
+			},
+		}},
+	}
+	r := NewLazyDeclTypeResolver(delegate)
+	next, err := r.FieldDeclType(gvk, "next")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next == nil {
+		t.Fatal("expected a DeclType for the self-referential field")
+	}
+}
+
+// lazyCountingResolver counts delegate resolutions.
+type lazyCountingResolver struct {
+	delegate SchemaResolver
+	calls    int
+}
+
+func (c *lazyCountingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	c.calls++
+	return c.delegate.ResolveSchema(gvk)
+}
+
+func BenchmarkEagerDeclTypeShallowField(b *testing.B) {
+	_, delegate := lazyTestResolver(256)
+	schemaOf := SchemaOfFromResolver(delegate)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		expanded, err := PopulateRefs(schemaOf, "example.com.v1.Widget")
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		declType := common.SchemaDeclType(&openapi.Schema{Schema: expanded}, true)
+		if declType.Fields["metadata"].Type.Fields["name"].Type.TypeName() != "string" {
+			b.Fatal("unexpected type for metadata.name")
+		}
+	}
+}
+
+func BenchmarkLazyDeclTypeShallowField(b *testing.B) {
+	gvk, delegate := lazyTestResolver(256)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r := NewLazyDeclTypeResolver(delegate)
+		name, err := r.FieldDeclType(gvk, "metadata", "name")
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		if name.TypeName() != "string" {
+			b.Fatal("unexpected type for metadata.name")
+		}
+	}
+}
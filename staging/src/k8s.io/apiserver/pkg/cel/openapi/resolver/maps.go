@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResolveSchemaAsMap resolves the schema identified by gvk using the given
+// resolver and returns it in the generic map[string]interface{} form, as
+// produced by marshaling the schema to JSON and unmarshaling it back.
+// Because the schema is fully resolved before conversion, the returned map
+// contains no "$ref" keys.
+func ResolveSchemaAsMap(r SchemaResolver, gvk schema.GroupVersionKind) (map[string]interface{}, error) {
+	s, err := r.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
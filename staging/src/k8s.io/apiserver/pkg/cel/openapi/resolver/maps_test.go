@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// fakeResolver is a SchemaResolver for testing that resolves any GVK to a
+// fixed schema, or returns a fixed error.
+type fakeResolver struct {
+	schema *spec.Schema
+	err    error
+}
+
+func (f *fakeResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.schema, nil
+}
+
+func TestResolveSchemaAsMap(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{
+						Ref: spec.MustCreateRef("#/definitions/Spec"),
+					}},
+				},
+			},
+		},
+		"#/definitions/Spec": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+				},
+			},
+		},
+	}
+	resolved, err := PopulateRefs(func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}, "#/definitions/Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Root"}
+	m, err := ResolveSchemaAsMap(&fakeResolver{schema: resolved}, gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spec, ok := m["properties"].(map[string]interface{})["spec"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected spec property in map form, got %v", m)
+	}
+	if _, ok := spec["properties"]; !ok {
+		t.Errorf("expected spec to be resolved with its own properties, got %v", spec)
+	}
+	assertNoRefKeys(t, "", m)
+}
+
+func TestResolveSchemaAsMapError(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Missing"}
+	if _, err := ResolveSchemaAsMap(&fakeResolver{err: ErrSchemaNotFound}, gvk); err == nil {
+		t.Error("expected error from the underlying resolver, got none")
+	}
+}
+
+// assertNoRefKeys fails the test if any "$ref" key appears anywhere in the
+// given generic JSON value.
+func assertNoRefKeys(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if k == "$ref" {
+				t.Errorf("unexpected $ref at %s", path)
+			}
+			assertNoRefKeys(t, path+"/"+k, val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			assertNoRefKeys(t, path, val)
+		}
+	}
+}
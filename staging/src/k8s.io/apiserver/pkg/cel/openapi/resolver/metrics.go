@@ -0,0 +1,32 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import "time"
+
+// ResolutionMetrics receives instrumentation about ref resolution, e.g. to
+// feed Prometheus collectors. Implementations must be safe for concurrent
+// use. No instrumentation code runs when no recorder is configured.
+type ResolutionMetrics interface {
+	// ObserveResolution is called once per resolution, successful or not,
+	// with the number of refs expanded, the number of schema nodes visited,
+	// and the wall-clock duration.
+	ObserveResolution(refs, nodes int, duration time.Duration)
+	// ObserveCacheHit is called by the memoizing RefPopulator each time a
+	// Populate call is served from its cache.
+	ObserveCacheHit()
+}
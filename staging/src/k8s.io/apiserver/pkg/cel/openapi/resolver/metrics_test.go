@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// fakeRecorder records the observations it receives.
+type fakeRecorder struct {
+	resolutions int
+	refs        int
+	nodes       int
+	duration    time.Duration
+	cacheHits   int
+}
+
+func (f *fakeRecorder) ObserveResolution(refs, nodes int, duration time.Duration) {
+	f.resolutions++
+	f.refs += refs
+	f.nodes += nodes
+	f.duration += duration
+}
+
+func (f *fakeRecorder) ObserveCacheHit() {
+	f.cacheHits++
+}
+
+func metricsTestSchemas() map[string]*spec.Schema {
+	return map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+				},
+			},
+		},
+		"Spec": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestResolutionMetrics(t *testing.T) {
+	schemas := metricsTestSchemas()
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	recorder := &fakeRecorder{}
+	if _, err := PopulateRefsWithOptions(schemaOf, "Root", Options{Metrics: recorder}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorder.resolutions != 1 {
+		t.Errorf("expected one observation, got %d", recorder.resolutions)
+	}
+	// the root and the spec ref
+	if recorder.refs != 2 {
+		t.Errorf("expected 2 refs resolved, got %d", recorder.refs)
+	}
+	// the root, the spec property, and the replicas property
+	if recorder.nodes != 3 {
+		t.Errorf("expected 3 nodes visited, got %d", recorder.nodes)
+	}
+}
+
+func TestResolutionMetricsCacheHits(t *testing.T) {
+	schemas := metricsTestSchemas()
+	recorder := &fakeRecorder{}
+	populator := NewRefPopulator(func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	})
+	populator.Metrics = recorder
+
+	if _, err := populator.Populate("Root"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := populator.Populate("Root"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorder.resolutions != 1 {
+		t.Errorf("expected one resolution observation, got %d", recorder.resolutions)
+	}
+	if recorder.cacheHits != 1 {
+		t.Errorf("expected one cache hit, got %d", recorder.cacheHits)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// multiResolver tries a list of resolvers in priority order.
+type multiResolver struct {
+	resolvers []SchemaResolver
+}
+
+var _ SchemaResolver = (*multiResolver)(nil)
+
+// NewMultiResolver returns a SchemaResolver that tries each of the given
+// resolvers in order and returns the first success, e.g. "check CRDs first,
+// then built-ins". A resolver that does not know the GVK is skipped; any
+// other error, e.g. a network failure, aborts the lookup immediately so it is
+// not mistaken for the type being absent. When every resolver misses, the
+// not-found errors are aggregated into one.
+func NewMultiResolver(resolvers ...SchemaResolver) SchemaResolver {
+	return &multiResolver{resolvers: resolvers}
+}
+
+func (r *multiResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	var misses []error
+	for _, resolver := range r.resolvers {
+		s, err := resolver.ResolveSchema(gvk)
+		if err == nil {
+			return s, nil
+		}
+		if !errors.Is(err, ErrSchemaNotFound) {
+			return nil, err
+		}
+		misses = append(misses, err)
+	}
+	if len(misses) == 0 {
+		return nil, fmt.Errorf("cannot resolve %v: %w", gvk, ErrSchemaNotFound)
+	}
+	return nil, utilerrors.NewAggregate(misses)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// erroringResolver fails every lookup with a fixed, non-not-found error.
+type erroringResolver struct {
+	err error
+}
+
+func (r erroringResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	return nil, r.err
+}
+
+func TestMultiResolver(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	primarySchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Description: "primary"}}
+	secondarySchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Description: "secondary"}}
+
+	// first hit wins
+	r := NewMultiResolver(mapResolver{gvk: primarySchema}, mapResolver{gvk: secondarySchema})
+	s, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Description != "primary" {
+		t.Errorf("expected the first resolver to win, got %q", s.Description)
+	}
+
+	// a miss falls through to the next resolver
+	r = NewMultiResolver(mapResolver{}, mapResolver{gvk: secondarySchema})
+	s, err = r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Description != "secondary" {
+		t.Errorf("expected fallthrough to the second resolver, got %q", s.Description)
+	}
+
+	// all misses aggregate into a not-found error
+	r = NewMultiResolver(mapResolver{}, mapResolver{})
+	if _, err := r.ResolveSchema(gvk); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound when every resolver misses, got %v", err)
+	}
+}
+
+func TestMultiResolverShortCircuitsOnOtherErrors(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	networkErr := errors.New("connection refused")
+	fallback := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+
+	r := NewMultiResolver(erroringResolver{err: networkErr}, mapResolver{gvk: fallback})
+	_, err := r.ResolveSchema(gvk)
+	if !errors.Is(err, networkErr) {
+		t.Errorf("expected the network error to short-circuit, got %v", err)
+	}
+}
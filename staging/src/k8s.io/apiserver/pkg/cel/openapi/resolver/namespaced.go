@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// NamespaceScopedResolver layers per-namespace schema overrides over a base
+// resolver, for multi-tenant clusters where namespaces may carry
+// namespace-scoped CRD variants.
+type NamespaceScopedResolver struct {
+	base      SchemaResolver
+	overrides map[string]SchemaResolver
+}
+
+var _ SchemaResolver = (*NamespaceScopedResolver)(nil)
+
+// NewNamespaceScopedResolver returns a resolver that consults the override
+// resolver registered for a namespace first and falls back to base when there
+// is no override or the override cannot find the schema.
+func NewNamespaceScopedResolver(base SchemaResolver, overrides map[string]SchemaResolver) *NamespaceScopedResolver {
+	return &NamespaceScopedResolver{base: base, overrides: overrides}
+}
+
+// ResolveSchema resolves without namespace context, using the base resolver
+// only.
+func (r *NamespaceScopedResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	return r.base.ResolveSchema(gvk)
+}
+
+// ResolveSchemaInNamespace resolves the schema for a request tagged with the
+// given namespace. The namespace's override resolver, if any, is consulted
+// first; if it reports not-found, resolution falls back to the base resolver.
+// Other override errors are returned as-is.
+func (r *NamespaceScopedResolver) ResolveSchemaInNamespace(namespace string, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	if override, ok := r.overrides[namespace]; ok {
+		s, err := override.ResolveSchema(gvk)
+		if err == nil {
+			return s, nil
+		}
+		if !errors.Is(err, ErrSchemaNotFound) {
+			return nil, err
+		}
+	}
+	return r.base.ResolveSchema(gvk)
+}
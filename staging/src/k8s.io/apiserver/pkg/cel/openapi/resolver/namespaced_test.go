@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestNamespaceScopedResolver(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	otherGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+	baseSchema := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: map[string]spec.Schema{"base": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+	}}
+	overrideSchema := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: map[string]spec.Schema{"override": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+	}}
+	r := NewNamespaceScopedResolver(
+		mapResolver{gvk: baseSchema, otherGVK: baseSchema},
+		map[string]SchemaResolver{
+			"tenant-a": mapResolver{gvk: overrideSchema},
+		},
+	)
+
+	// override hit: the namespace-specific schema wins
+	s, err := r.ResolveSchemaInNamespace("tenant-a", gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s.Properties["override"]; !ok {
+		t.Error("expected the override schema for tenant-a")
+	}
+
+	// override miss: the override resolver does not know the GVK, fall back
+	s, err = r.ResolveSchemaInNamespace("tenant-a", otherGVK)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s.Properties["base"]; !ok {
+		t.Error("expected fallback to the base schema on override miss")
+	}
+
+	// base fallback: no override registered for the namespace
+	s, err = r.ResolveSchemaInNamespace("tenant-b", gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s.Properties["base"]; !ok {
+		t.Error("expected the base schema for a namespace without overrides")
+	}
+
+	// namespace-less resolution uses the base only
+	s, err = r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s.Properties["base"]; !ok {
+		t.Error("expected ResolveSchema to use the base resolver")
+	}
+}
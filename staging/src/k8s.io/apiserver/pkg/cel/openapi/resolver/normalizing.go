@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// normalizingResolver canonicalizes GVKs before delegating, so that a strict
+// delegate can tolerate loose inputs (e.g. lowercased or pluralized kinds) at
+// the edge.
+type normalizingResolver struct {
+	delegate  SchemaResolver
+	normalize func(schema.GroupVersionKind) schema.GroupVersionKind
+}
+
+var _ SchemaResolver = (*normalizingResolver)(nil)
+
+// NewNormalizingResolver returns a SchemaResolver that applies the given
+// normalize function to every GVK before delegating. Callers can plug in e.g.
+// RESTMapper-based normalization. A nil normalize function leaves GVKs
+// untouched.
+func NewNormalizingResolver(delegate SchemaResolver, normalize func(schema.GroupVersionKind) schema.GroupVersionKind) SchemaResolver {
+	return &normalizingResolver{delegate: delegate, normalize: normalize}
+}
+
+func (r *normalizingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	if r.normalize != nil {
+		gvk = r.normalize(gvk)
+	}
+	return r.delegate.ResolveSchema(gvk)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestNormalizingResolver(t *testing.T) {
+	canonical := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	delegate := mapResolver{
+		canonical: {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	r := NewNormalizingResolver(delegate, func(gvk schema.GroupVersionKind) schema.GroupVersionKind {
+		gvk.Kind = strings.Title(strings.ToLower(gvk.Kind)) //nolint:staticcheck // kinds are single words
+		return gvk
+	})
+
+	// the loose form resolves via normalization
+	loose := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "widget"}
+	if _, err := r.ResolveSchema(loose); err != nil {
+		t.Errorf("unexpected error resolving lowercase kind: %v", err)
+	}
+	// the delegate stays strict
+	if _, err := delegate.ResolveSchema(loose); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected the delegate to reject the loose form, got %v", err)
+	}
+}
+
+func TestNormalizingResolverNilNormalize(t *testing.T) {
+	canonical := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	delegate := mapResolver{
+		canonical: {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	r := NewNormalizingResolver(delegate, nil)
+	if _, err := r.ResolveSchema(canonical); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/utils/clock"
+)
+
+// RevisionedResolver is a SchemaResolver whose backing schema source reports
+// an opaque revision that changes whenever any of its schemas change.
+type RevisionedResolver interface {
+	SchemaResolver
+	// SourceRevision returns the current revision of the schema source.
+	SourceRevision() string
+}
+
+// NotifyingResolver caches schemas from a revisioned delegate and polls the
+// delegate's revision. When the revision changes, the cache is invalidated
+// and the previously cached GVKs are emitted on Changes, so that controllers
+// recompiling CEL expressions can react without polling themselves.
+type NotifyingResolver struct {
+	delegate RevisionedResolver
+	poll     time.Duration
+	changes  chan schema.GroupVersionKind
+	clock    clock.WithTicker
+
+	mu       sync.Mutex
+	revision string
+	cache    map[schema.GroupVersionKind]*spec.Schema
+}
+
+var _ SchemaResolver = (*NotifyingResolver)(nil)
+
+// NewNotifyingResolver returns a NotifyingResolver polling the delegate's
+// revision at the given interval. The caller must invoke Run to start
+// polling.
+func NewNotifyingResolver(delegate RevisionedResolver, poll time.Duration) *NotifyingResolver {
+	return &NotifyingResolver{
+		delegate: delegate,
+		poll:     poll,
+		changes:  make(chan schema.GroupVersionKind, 64),
+		clock:    clock.RealClock{},
+		revision: delegate.SourceRevision(),
+		cache:    map[schema.GroupVersionKind]*spec.Schema{},
+	}
+}
+
+// Changes returns the channel on which invalidated GVKs are delivered. If the
+// channel's buffer fills because no one is receiving, further notifications
+// are dropped.
+func (r *NotifyingResolver) Changes() <-chan schema.GroupVersionKind {
+	return r.changes
+}
+
+// ResolveSchema resolves the schema for gvk, serving repeated resolutions of
+// the same GVK from the cache until the source revision changes.
+func (r *NotifyingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[gvk]
+	r.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.cache[gvk] = s
+	r.mu.Unlock()
+	return s, nil
+}
+
+// Run polls the delegate's revision until stopCh is closed.
+func (r *NotifyingResolver) Run(stopCh <-chan struct{}) {
+	ticker := r.clock.NewTicker(r.poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C():
+			r.checkRevision()
+		}
+	}
+}
+
+// checkRevision invalidates the cache and notifies the previously cached
+// GVKs if the delegate's revision has changed.
+func (r *NotifyingResolver) checkRevision() {
+	revision := r.delegate.SourceRevision()
+	r.mu.Lock()
+	if revision == r.revision {
+		r.mu.Unlock()
+		return
+	}
+	r.revision = revision
+	invalidated := make([]schema.GroupVersionKind, 0, len(r.cache))
+	for gvk := range r.cache {
+		invalidated = append(invalidated, gvk)
+	}
+	r.cache = map[schema.GroupVersionKind]*spec.Schema{}
+	r.mu.Unlock()
+
+	for _, gvk := range invalidated {
+		select {
+		case r.changes <- gvk:
+		default:
+			// no receiver is keeping up; drop the notification rather than
+			// block polling
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// revisionedMapResolver is a mapResolver with a settable source revision and
+// a resolution counter.
+type revisionedMapResolver struct {
+	mu       sync.Mutex
+	schemas  mapResolver
+	revision string
+	resolves int
+}
+
+func (r *revisionedMapResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	r.mu.Lock()
+	r.resolves++
+	r.mu.Unlock()
+	return r.schemas.ResolveSchema(gvk)
+}
+
+func (r *revisionedMapResolver) SourceRevision() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.revision
+}
+
+func (r *revisionedMapResolver) setRevision(revision string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revision = revision
+}
+
+func (r *revisionedMapResolver) resolveCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.resolves
+}
+
+func TestNotifyingResolver(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	delegate := &revisionedMapResolver{
+		schemas: mapResolver{
+			gvk: {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+		},
+		revision: "1",
+	}
+	r := NewNotifyingResolver(delegate, time.Second)
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	r.clock = fakeClock
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go r.Run(stopCh)
+
+	// repeated resolutions are served from the cache
+	for i := 0; i < 2; i++ {
+		if _, err := r.ResolveSchema(gvk); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := delegate.resolveCount(); got != 1 {
+		t.Fatalf("expected a single delegate resolution, got %d", got)
+	}
+
+	// wait for the poll loop to arm its ticker, then advance past one poll
+	// interval with an unchanged revision: no notification
+	for !fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	fakeClock.Step(time.Second)
+	select {
+	case got := <-r.Changes():
+		t.Fatalf("unexpected notification without a revision change: %v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// a revision change notifies the cached GVK and invalidates the cache
+	delegate.setRevision("2")
+	fakeClock.Step(time.Second)
+	select {
+	case got := <-r.Changes():
+		if got != gvk {
+			t.Errorf("expected notification for %v, got %v", gvk, got)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for a change notification")
+	}
+	if _, err := r.ResolveSchema(gvk); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := delegate.resolveCount(); got != 2 {
+		t.Errorf("expected the cache to be invalidated, got %d delegate resolutions", got)
+	}
+}
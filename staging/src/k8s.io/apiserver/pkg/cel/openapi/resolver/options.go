@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+// Options configures the optional behaviors of PopulateRefsWithOptions.
+// The zero value of Options yields the same behavior as PopulateRefs.
+type Options struct {
+	// MaxPropertiesPerObject, if greater than zero, limits how many properties
+	// any resolved object may declare. Resolution fails with an error that
+	// reports the offending path and its property count if the limit is
+	// exceeded. This bounds the cost of typing pathological generated schemas.
+	MaxPropertiesPerObject int
+
+	// Allocator, if set, supplies the schema copies the resolver creates,
+	// allowing callers to pool and reuse them across resolutions.
+	Allocator SchemaAllocator
+
+	// NormalizeEmptySlices replaces empty (but non-nil) required, enum, and
+	// combinator slices with nil in the resolved output, so that "required: []"
+	// and an absent required produce identical schemas. This keeps hashes and
+	// diffs of resolved schemas stable. Caller-provided schemas are not
+	// modified.
+	NormalizeEmptySlices bool
+
+	// RejectUnknownKeywords fails resolution when a schema node carries
+	// keywords that were not recognized during unmarshaling and are not "x-"
+	// extensions. Such keywords are captured in ExtraProps and usually
+	// indicate document corruption or typos (e.g. "requird").
+	RejectUnknownKeywords bool
+
+	// RejectMetaSchemaKeywords fails resolution when a schema node carries
+	// the meta-schema keywords id, $id, or $schema, which Kubernetes
+	// structural schemas forbid and which indicate a non-structural document.
+	// All occurrences are reported together in one aggregated error, each
+	// with its path.
+	RejectMetaSchemaKeywords bool
+
+	// ValidateBoundOrdering checks that paired bounds are ordered, i.e.
+	// minItems <= maxItems, minLength <= maxLength, minProperties <=
+	// maxProperties and minimum <= maximum. All violations are reported
+	// together in one aggregated error, each with its path.
+	ValidateBoundOrdering bool
+
+	// ValidateEnumFormat checks that enum values on string nodes declaring a
+	// known format (e.g. date-time) actually parse as that format. All
+	// violations are reported together in one aggregated error, each with its
+	// path.
+	ValidateEnumFormat bool
+
+	// ValidateRequiredReadOnly reports fields that are both required and
+	// readOnly, a contradiction on create: the user can neither omit nor set
+	// them. All conflicts are reported together in one aggregated error, each
+	// with its path.
+	ValidateRequiredReadOnly bool
+
+	// ValidateEnumTypes checks that every enum value on a typed node is
+	// consistent with the node's declared type, e.g. that an integer node
+	// does not enumerate strings. The first inconsistent value of each node
+	// is reported with its path.
+	ValidateEnumTypes bool
+
+	// AnnotateLeafOrigin marks every resolved scalar leaf with the
+	// x-kubernetes-schema-origin extension naming the nearest enclosing
+	// definition it was resolved from, e.g. for CEL diagnostics that say
+	// "expected string (from io.k8s...ObjectMeta.name)". The extension is
+	// added on copies only; caller-provided schemas are not modified.
+	AnnotateLeafOrigin bool
+
+	// Dialect selects the JSON Schema dialect rules enforced during
+	// resolution. The zero value is DialectV3, which is permissive.
+	Dialect SchemaDialect
+
+	// CheckCELReservedNames reports resolved property names that collide
+	// with CEL reserved identifiers (or cannot be represented in CEL at
+	// all), so that CRD authors know escaping will be required. All
+	// collisions are reported together in one aggregated error, each with
+	// its path.
+	CheckCELReservedNames bool
+
+	// MaxResolvedBytes, if greater than zero, bounds the approximate
+	// serialized size of the resolved schema. Resolution aborts with an
+	// error once the running total exceeds the limit. This is a coarse
+	// safety valve against a single type inflating into a giant resolved
+	// schema that bloats caches.
+	MaxResolvedBytes int
+
+	// MaxNodes, if greater than zero, bounds how many schema nodes the
+	// expansion may visit, counting nested properties, items, and combinator
+	// members. Resolution aborts with an error wrapping ErrSchemaTooLarge
+	// once the budget is exceeded, and no partial schema is returned. Zero
+	// or negative means unbounded, matching PopulateRefs; callers expanding
+	// untrusted documents should set a limit, for which 100000 nodes is a
+	// reasonable starting point.
+	MaxNodes int
+
+	// MaxDepth, if greater than zero, bounds how many refs deep resolution
+	// expands; refs nested beyond the limit are left in place rather than
+	// expanded. Zero or negative means unlimited, matching PopulateRefs.
+	// This bounds the in-memory size of fully expanded aggregated schemas.
+	MaxDepth int
+
+	// UnexpandedRefs, if non-nil, receives the number of refs left
+	// unexpanded because of MaxDepth, so callers can decide whether to retry
+	// with a higher budget.
+	UnexpandedRefs *int
+
+	// Unresolved, if non-nil, receives the sorted set of refs for which
+	// schemaOf reported not found, instead of the first such ref aborting
+	// resolution with an UnresolvedRefError. Each unresolved Ref is left in
+	// place in the output so a later pass can retry once the missing
+	// definitions have been loaded.
+	Unresolved *[]string
+
+	// ResolutionPaths, if non-nil, is populated with the sequence of refs
+	// traversed to reach each node at which a ref was resolved, keyed by the
+	// node's field path ("<root>" for the root schema). It answers "why does
+	// this field have this type" for deeply composed schemas.
+	ResolutionPaths map[string][]string
+
+	// FailOnCycle returns a CyclicRefError when a reference cycle is
+	// encountered instead of substituting the empty object placeholder.
+	FailOnCycle bool
+
+	// OnResolveRef, if set, is invoked just before each ref lookup with the
+	// ref about to be resolved, including the root ref. Callers can use it to
+	// lazily load the referred definition into the backing store, or to audit
+	// or deny specific refs. A non-nil return aborts resolution with that
+	// error.
+	OnResolveRef func(ref string) error
+
+	// ResolveAlias, if set, is consulted when schemaOf reports a ref not
+	// found, to translate the ref into an alternate name to retry, e.g.
+	// mapping a short name like "Address" to the fully qualified
+	// "com.example.v1.Address". See NameAliases and SuffixAliases for
+	// ready-made callbacks. Returning "" with a nil error means no alias is
+	// known and the usual unresolved handling applies; a non-nil error (e.g.
+	// an ambiguous short name) aborts resolution. Exact matches always win:
+	// the callback runs only after schemaOf has missed.
+	ResolveAlias func(ref string) (string, error)
+
+	// InferImplicitTypes sets "type: object" on resolved nodes that declare
+	// properties without a type, and "type: array" on nodes that declare only
+	// items, so that every structural node is explicitly typed for CEL. The
+	// type is set on copies only; caller-provided schemas are not modified.
+	InferImplicitTypes bool
+
+	// ExpandPreserveUnknownFields normalizes every resolved node carrying
+	// x-kubernetes-preserve-unknown-fields into an explicit object with
+	// "additionalProperties: true", so that downstream CEL typing uniformly
+	// sees a dyn map. The extension itself is retained alongside the
+	// normalized form. Nodes are normalized on copies only; caller-provided
+	// schemas are not modified.
+	ExpandPreserveUnknownFields bool
+
+	// Parallelism, if greater than one, expands the root schema's property
+	// subtrees concurrently across at most that many workers; the subtrees
+	// are independent and merge back deterministically. The zero value is
+	// the serial path. Options that accumulate state across the whole
+	// expansion (MaxResolvedBytes, MaxNodes, UnexpandedRefs, Unresolved,
+	// ResolutionPaths, Metrics, Allocator) force the serial path, and an
+	// OnResolveRef hook must be safe for concurrent use.
+	Parallelism int
+
+	// Trace, if non-nil, is filled with a per-call breakdown of the
+	// expansion: ref visit counts, per-ref node counts, the deepest ref
+	// nesting, and the number of distinct refs. See ResolutionTrace.
+	Trace *ResolutionTrace
+
+	// Metrics, if set, receives instrumentation about the resolution: the
+	// number of refs expanded, the number of schema nodes visited, and the
+	// wall-clock duration. See ResolutionMetrics.
+	Metrics ResolutionMetrics
+
+	// ScalarRefs maps definition names that are effectively scalars, such as
+	// meta/v1 Time, to the scalar leaf to substitute instead of expanding
+	// the definition into its object form, which would confuse CEL typing.
+	// Values are an OpenAPI type, optionally followed by ":" and a format
+	// (e.g. "string:date-time"), or the special "int-or-string". See
+	// DefaultScalarRefs for the standard Kubernetes set.
+	ScalarRefs map[string]string
+
+	// UseEmbeddedDefinitions consults the root schema's own Definitions map
+	// when schemaOf misses, so a self-contained schema whose refs point at
+	// its local "#/definitions/..." entries resolves without the caller
+	// indexing them separately. Refs inside the local definitions resolve
+	// the same way.
+	UseEmbeddedDefinitions bool
+
+	// ShareIdenticalSubtrees reuses a single resolved copy for every
+	// occurrence of the same ref instead of expanding it again, which
+	// sharply cuts memory for types like Deployment that embed PodSpec many
+	// times. The resolved schema must then be treated as immutable: the
+	// shared subtrees alias each other, so mutating one occurrence corrupts
+	// the rest. Subtrees are not shared when the referencing node carries
+	// annotations to merge or when the expansion collapsed a cycle, and the
+	// option is ignored alongside options whose output or accounting depends
+	// on where a ref occurs (Allocator, AnnotateLeafOrigin, ResolutionPaths,
+	// Unresolved, UnexpandedRefs, MaxDepth, MaxNodes, Metrics).
+	ShareIdenticalSubtrees bool
+
+	// MergeValidationRules resolves allOf branches and hoists their
+	// x-kubernetes-validations rules onto the composing schema, deduplicating
+	// identical rules, so that CEL sees the complete effective rule set in
+	// one place. The hoisted rules are removed from the branch copies;
+	// caller-provided schemas are not modified.
+	MergeValidationRules bool
+}
+
+// SchemaDialect selects which JSON Schema dialect rules apply during
+// resolution.
+type SchemaDialect int
+
+const (
+	// DialectV3 allows sibling keywords next to $ref, as OpenAPI v3.1 does.
+	DialectV3 SchemaDialect = iota
+	// DialectV2Strict forbids sibling keywords next to $ref, as OpenAPI v2
+	// does. Every occurrence is collected and reported with its path in one
+	// aggregated error, which helps locate where siblings were relied upon
+	// when migrating a v2 document to v3.
+	DialectV2Strict
+)
@@ -0,0 +1,1040 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestMaxPropertiesPerObject(t *testing.T) {
+	wide := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"a": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"b": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"c": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Ref: spec.MustCreateRef("#/definitions/Wide"),
+				}},
+			},
+		},
+	}
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": root,
+		"#/definitions/Wide": wide,
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	// just at the limit: resolution succeeds
+	if _, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MaxPropertiesPerObject: 3}); err != nil {
+		t.Errorf("unexpected error with limit 3: %v", err)
+	}
+	// just over the limit: resolution fails, reporting path and count
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MaxPropertiesPerObject: 2})
+	if err == nil {
+		t.Fatal("expected error with limit 2, got none")
+	}
+	if !strings.Contains(err.Error(), `"spec"`) {
+		t.Errorf("expected error to report the offending path, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "3 properties") {
+		t.Errorf("expected error to report the property count, got %v", err)
+	}
+}
+
+func TestNormalizeEmptySlices(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{},
+			Properties: map[string]spec.Schema{
+				"kind": {SchemaProps: spec.SchemaProps{
+					Type: []string{"string"},
+					Enum: []interface{}{},
+				}},
+				"phase": {SchemaProps: spec.SchemaProps{
+					Type:     []string{"string"},
+					Enum:     []interface{}{"Pending", "Running"},
+					Required: nil,
+				}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+
+	result, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{NormalizeEmptySlices: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Required != nil {
+		t.Errorf("expected empty required to normalize to nil, got %v", result.Required)
+	}
+	if result.Properties["kind"].Enum != nil {
+		t.Errorf("expected empty enum to normalize to nil, got %v", result.Properties["kind"].Enum)
+	}
+	if got := result.Properties["phase"].Enum; len(got) != 2 {
+		t.Errorf("expected non-empty enum to be preserved, got %v", got)
+	}
+	// the original schema must be untouched
+	if root.Required == nil {
+		t.Error("original schema was mutated")
+	}
+
+	// without the option, the empty slices pass through unchanged
+	plain, err := PopulateRefs(schemaOf, "#/definitions/Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.Required == nil {
+		t.Error("expected empty required to be preserved without the option")
+	}
+}
+
+func TestRejectUnknownKeywords(t *testing.T) {
+	// unmarshal so that the unknown keyword is captured in ExtraProps, the
+	// same way a corrupted document would arrive
+	var root spec.Schema
+	doc := `{"type": "object", "properties": {"name": {"type": "string", "requird": ["name"]}}}`
+	if err := root.UnmarshalJSON([]byte(doc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return &root, true
+		}
+		return nil, false
+	}
+
+	// without the option the typo passes through
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error without the option: %v", err)
+	}
+	// with the option, resolution fails and names the keyword
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{RejectUnknownKeywords: true})
+	if err == nil {
+		t.Fatal("expected error for unknown keyword, got none")
+	}
+	if !strings.Contains(err.Error(), "requird") {
+		t.Errorf("expected error to name the unknown keyword, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"name"`) {
+		t.Errorf("expected error to report the path, got %v", err)
+	}
+}
+
+func TestValidateBoundOrdering(t *testing.T) {
+	minItems, maxItems := int64(5), int64(2)
+	minLength, maxLength := int64(10), int64(3)
+	minProps, maxProps := int64(4), int64(1)
+	minimum, maximum := 7.0, 6.0
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"list": {SchemaProps: spec.SchemaProps{
+					Type:     []string{"array"},
+					MinItems: &minItems,
+					MaxItems: &maxItems,
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+					}},
+				}},
+				"name": {SchemaProps: spec.SchemaProps{
+					Type:      []string{"string"},
+					MinLength: &minLength,
+					MaxLength: &maxLength,
+				}},
+				"labels": {SchemaProps: spec.SchemaProps{
+					Type:          []string{"object"},
+					MinProperties: &minProps,
+					MaxProperties: &maxProps,
+				}},
+				"replicas": {SchemaProps: spec.SchemaProps{
+					Type:    []string{"integer"},
+					Minimum: &minimum,
+					Maximum: &maximum,
+				}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+
+	// without the option the violations pass through
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error without the option: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{ValidateBoundOrdering: true})
+	if err == nil {
+		t.Fatal("expected aggregated bound ordering error, got none")
+	}
+	for _, want := range []string{"minItems", "minLength", "minProperties", "minimum", `"list"`, `"name"`, `"labels"`, `"replicas"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected aggregated error to mention %s, got %v", want, err)
+		}
+	}
+}
+
+func TestValidateEnumFormat(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"deadline": {SchemaProps: spec.SchemaProps{
+					Type:   []string{"string"},
+					Format: "date-time",
+					Enum:   []interface{}{"2026-01-02T15:04:05Z", "not-a-timestamp"},
+				}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+
+	// without the option the invalid enum value passes through
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error without the option: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{ValidateEnumFormat: true})
+	if err == nil {
+		t.Fatal("expected enum format error, got none")
+	}
+	if !strings.Contains(err.Error(), "not-a-timestamp") {
+		t.Errorf("expected error to name the invalid value, got %v", err)
+	}
+	if strings.Contains(err.Error(), "2026-01-02T15:04:05Z") {
+		t.Errorf("valid enum value was reported as a violation: %v", err)
+	}
+	if !strings.Contains(err.Error(), `"deadline"`) {
+		t.Errorf("expected error to report the path, got %v", err)
+	}
+}
+
+func TestAnnotateLeafOrigin(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+				},
+			},
+		},
+		"#/definitions/Spec": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	result, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{AnnotateLeafOrigin: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name := result.Properties["name"]
+	if got, _ := name.Extensions.GetString(extSchemaOrigin); got != "#/definitions/Root" {
+		t.Errorf("expected root leaf origin %q, got %q", "#/definitions/Root", got)
+	}
+	replicas := result.Properties["spec"].Properties["replicas"]
+	if got, _ := replicas.Extensions.GetString(extSchemaOrigin); got != "#/definitions/Spec" {
+		t.Errorf("expected nested leaf origin %q, got %q", "#/definitions/Spec", got)
+	}
+	// non-leaf nodes are not annotated
+	if _, ok := result.Properties["spec"].Extensions.GetString(extSchemaOrigin); ok {
+		t.Error("unexpected origin annotation on a non-leaf node")
+	}
+	// the originals are untouched
+	rootName := schemas["#/definitions/Root"].Properties["name"]
+	if len(rootName.Extensions) != 0 {
+		t.Error("original schema was mutated")
+	}
+}
+
+func TestDialectV2StrictRefSiblings(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"first": {SchemaProps: spec.SchemaProps{
+						Ref:         spec.MustCreateRef("#/definitions/Leaf"),
+						Description: "sibling next to ref",
+					}},
+					"second": {SchemaProps: spec.SchemaProps{
+						Ref:  spec.MustCreateRef("#/definitions/Leaf"),
+						Type: []string{"object"},
+					}},
+					"clean": {SchemaProps: spec.SchemaProps{
+						Ref: spec.MustCreateRef("#/definitions/Leaf"),
+					}},
+				},
+			},
+		},
+		"#/definitions/Leaf": {
+			SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	// the default dialect allows siblings
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error under the default dialect: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{Dialect: DialectV2Strict})
+	if err == nil {
+		t.Fatal("expected ref sibling violations under v2-strict, got none")
+	}
+	agg, ok := err.(utilerrors.Aggregate)
+	if !ok {
+		t.Fatalf("expected an aggregated error, got %T: %v", err, err)
+	}
+	if len(agg.Errors()) != 2 {
+		t.Errorf("expected 2 ref+sibling occurrences, got %d: %v", len(agg.Errors()), agg)
+	}
+	for _, want := range []string{`"first"`, `"second"`, "description", "type"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected aggregated error to mention %s, got %v", want, err)
+		}
+	}
+}
+
+func TestCheckCELReservedNames(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"namespace": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"replicas":  {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+
+	// without the option the reserved name passes through
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error without the option: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{CheckCELReservedNames: true})
+	if err == nil {
+		t.Fatal("expected reserved name collision error, got none")
+	}
+	if !strings.Contains(err.Error(), `"namespace"`) || !strings.Contains(err.Error(), "__namespace__") {
+		t.Errorf("expected error to report the reserved name and its escaped form, got %v", err)
+	}
+	if strings.Contains(err.Error(), "replicas") {
+		t.Errorf("unexpected report for a non-reserved property name: %v", err)
+	}
+}
+
+func TestMaxResolvedBytes(t *testing.T) {
+	// a small definition referenced repeatedly inflates the resolved schema
+	big := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:        []string{"object"},
+			Description: strings.Repeat("very detailed documentation ", 10),
+			Properties: map[string]spec.Schema{
+				"value": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"a": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Big")}},
+				"b": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Big")}},
+				"c": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Big")}},
+			},
+		},
+	}
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": root,
+		"#/definitions/Big":  big,
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	if _, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MaxResolvedBytes: 1 << 20}); err != nil {
+		t.Errorf("unexpected error with a generous limit: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MaxResolvedBytes: 300})
+	if err == nil {
+		t.Fatal("expected error for a schema inflating past the limit, got none")
+	}
+	if !strings.Contains(err.Error(), "maximum size of 300 bytes") {
+		t.Errorf("expected error to report the limit, got %v", err)
+	}
+}
+
+func TestMaxPropertiesPerObjectZeroDisables(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"a": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"b": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	if _, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{}); err != nil {
+		t.Errorf("unexpected error with no limit: %v", err)
+	}
+}
+
+func TestMergeValidationRules(t *testing.T) {
+	rule := func(r string) interface{} {
+		return map[string]interface{}{"rule": r}
+	}
+	root := &spec.Schema{
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+			extValidations: []interface{}{rule("self.size > 0")},
+		}},
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			AllOf: []spec.Schema{
+				{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+					extValidations: []interface{}{rule("self.a > 0"), rule("self.b > 0")},
+				}}},
+				{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+					// self.b > 0 repeats the first branch and is deduplicated
+					extValidations: []interface{}{rule("self.b > 0"), rule("self.c > 0")},
+				}}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MergeValidationRules: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, ok := resolved.Extensions[extValidations].([]interface{})
+	if !ok {
+		t.Fatalf("expected merged rules on the parent, got %v", resolved.Extensions[extValidations])
+	}
+	if len(merged) != 4 {
+		t.Errorf("expected 4 deduplicated rules, got %d: %v", len(merged), merged)
+	}
+	for i, branch := range resolved.AllOf {
+		if _, ok := branch.Extensions[extValidations]; ok {
+			t.Errorf("expected rules hoisted off branch %d", i)
+		}
+	}
+	// the caller-provided schema is untouched
+	if _, ok := root.AllOf[0].Extensions[extValidations]; !ok {
+		t.Error("expected the original schema to keep its rules")
+	}
+}
+
+func TestInferImplicitTypes(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			// properties without a declared type
+			Properties: map[string]spec.Schema{
+				"tags": {SchemaProps: spec.SchemaProps{
+					// items without a declared type
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+					}},
+				}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{InferImplicitTypes: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resolved.Type.Contains("object") {
+		t.Errorf("expected object inferred for the properties-only root, got %v", resolved.Type)
+	}
+	if got := resolved.Properties["tags"].Type; !got.Contains("array") {
+		t.Errorf("expected array inferred for the items-only node, got %v", got)
+	}
+	// the caller-provided schema is untouched
+	if len(root.Type) != 0 {
+		t.Errorf("expected the original schema to stay untyped, got %v", root.Type)
+	}
+}
+
+func TestOnResolveRefLazyLoading(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	// the hook loads the missing definition just in time.
+	onResolveRef := func(ref string) error {
+		if _, ok := schemas[ref]; !ok {
+			schemas[ref] = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+		}
+		return nil
+	}
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{OnResolveRef: onResolveRef})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resolved.Properties["spec"].Type.Contains("object") {
+		t.Error("expected the lazily loaded definition to be resolved")
+	}
+}
+
+func TestOnResolveRefDeny(t *testing.T) {
+	errDenied := errors.New("denied")
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secret": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Secret")}},
+				},
+			},
+		},
+		"#/definitions/Secret": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	onResolveRef := func(ref string) error {
+		if ref == "#/definitions/Secret" {
+			return errDenied
+		}
+		return nil
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{OnResolveRef: onResolveRef})
+	if !errors.Is(err, errDenied) {
+		t.Errorf("expected the hook error to abort resolution, got %v", err)
+	}
+}
+
+func TestValidateEnumTypes(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"severity": {SchemaProps: spec.SchemaProps{
+					Type: []string{"integer"},
+					// a mix of strings and integers under an integer type
+					Enum: []interface{}{"low", float64(2), "high"},
+				}},
+				"mode": {SchemaProps: spec.SchemaProps{
+					Type: []string{"string"},
+					Enum: []interface{}{"on", "off"},
+				}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{ValidateEnumTypes: true})
+	if err == nil {
+		t.Fatal("expected error for a mixed-type enum, got none")
+	}
+	if !strings.Contains(err.Error(), `enum value low at "severity" is not of type [integer]`) {
+		t.Errorf("expected the first inconsistent value with its path, got %v", err)
+	}
+	if strings.Contains(err.Error(), "mode") {
+		t.Errorf("expected the consistent enum to pass, got %v", err)
+	}
+}
+
+func TestExpandPreserveUnknownFields(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"config": {VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+					extPreserveUnknownFields: true,
+				}}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{ExpandPreserveUnknownFields: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := resolved.Properties["config"]
+	if !config.Type.Contains("object") {
+		t.Errorf("expected preserve-unknown-fields node to be typed as object, got %v", config.Type)
+	}
+	if config.AdditionalProperties == nil || !config.AdditionalProperties.Allows {
+		t.Error("expected preserve-unknown-fields node to allow additionalProperties")
+	}
+	if preserve, _ := config.Extensions.GetBool(extPreserveUnknownFields); !preserve {
+		t.Error("expected the extension to be retained alongside the normalized form")
+	}
+	// the caller-provided schema is untouched
+	if orig := root.Properties["config"]; len(orig.Type) != 0 || orig.AdditionalProperties != nil {
+		t.Error("original schema was mutated")
+	}
+}
+
+func TestValidateRequiredReadOnly(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{"uid", "name"},
+			Properties: map[string]spec.Schema{
+				// required and readOnly: contradiction on create
+				"uid": {SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true},
+					SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return root, true
+		}
+		return nil, false
+	}
+
+	// without the option the conflict passes through
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error without the option: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{ValidateRequiredReadOnly: true})
+	if err == nil {
+		t.Fatal("expected error for a required readOnly field, got none")
+	}
+	if !strings.Contains(err.Error(), `field "uid"`) {
+		t.Errorf("expected error to name the conflicting field, got %v", err)
+	}
+	if strings.Contains(err.Error(), `"name"`) {
+		t.Errorf("expected the normal field to pass, got %v", err)
+	}
+}
+
+func TestResolutionPaths(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+				},
+			},
+		},
+		"#/definitions/Spec": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"template": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Template")}},
+					"name":     {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			},
+		},
+		"#/definitions/Template": {
+			SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	paths := map[string][]string{}
+	if _, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{ResolutionPaths: paths}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{
+		"<root>":        {"#/definitions/Root"},
+		"spec":          {"#/definitions/Root", "#/definitions/Spec"},
+		"spec.template": {"#/definitions/Root", "#/definitions/Spec", "#/definitions/Template"},
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("unexpected resolution paths:\ngot  %v\nwant %v", paths, want)
+	}
+}
+
+func TestRejectMetaSchemaKeywords(t *testing.T) {
+	// unmarshal so that $id lands in ExtraProps the same way a foreign
+	// document would arrive
+	var root spec.Schema
+	doc := `{
+		"type": "object",
+		"properties": {
+			"tainted": {"type": "object", "$id": "https://example.com/schema", "$schema": "http://json-schema.org/draft-07/schema#"},
+			"clean": {"type": "string"}
+		}
+	}`
+	if err := root.UnmarshalJSON([]byte(doc)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "#/definitions/Root" {
+			return &root, true
+		}
+		return nil, false
+	}
+
+	// without the option the keywords pass through
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err != nil {
+		t.Errorf("unexpected error without the option: %v", err)
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{RejectMetaSchemaKeywords: true})
+	if err == nil {
+		t.Fatal("expected error for meta-schema keywords, got none")
+	}
+	for _, want := range []string{`"$id"`, `"$schema"`, `"tainted"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "clean") {
+		t.Errorf("expected the clean node to pass, got %v", err)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+				},
+			},
+		},
+		"#/definitions/Spec": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"template": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Template")}},
+				},
+			},
+		},
+		"#/definitions/Template": {
+			SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	// depth 1: the first-level ref expands, the nested one is left in place
+	unexpanded := 0
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{MaxDepth: 1, UnexpandedRefs: &unexpanded})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spec1 := resolved.Properties["spec"]
+	if len(spec1.Properties) == 0 {
+		t.Error("expected the first-level ref to be expanded")
+	}
+	template := spec1.Properties["template"]
+	if template.Ref.GetURL() == nil {
+		t.Error("expected the nested ref to be left in place")
+	}
+	if unexpanded != 1 {
+		t.Errorf("expected 1 unexpanded ref, got %d", unexpanded)
+	}
+
+	// the zero value remains unlimited
+	unexpanded = 0
+	resolved, err = PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{UnexpandedRefs: &unexpanded})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spec2 := resolved.Properties["spec"]
+	innermost := spec2.Properties["template"]
+	if innermost.Ref.GetURL() != nil {
+		t.Error("expected unlimited expansion by default")
+	}
+	if unexpanded != 0 {
+		t.Errorf("expected no unexpanded refs, got %d", unexpanded)
+	}
+}
+
+func TestUnresolvedRefsCollected(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec":   {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Spec")}},
+					"status": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Status")}},
+					"extra":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Extra")}},
+				},
+			},
+		},
+		"#/definitions/Spec": {
+			SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	var unresolved []string
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{Unresolved: &unresolved})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"#/definitions/Extra", "#/definitions/Status"}
+	if !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("expected unresolved refs %v, got %v", want, unresolved)
+	}
+	status := resolved.Properties["status"]
+	if status.Ref.GetURL() == nil {
+		t.Error("expected the unresolved ref to be left in place")
+	}
+	spec1 := resolved.Properties["spec"]
+	if spec1.Ref.GetURL() != nil {
+		t.Error("expected the resolvable ref to be expanded")
+	}
+
+	// without the option, the first miss aborts resolution
+	if _, err := PopulateRefs(schemaOf, "#/definitions/Root"); err == nil {
+		t.Error("expected an error without the option")
+	}
+}
+
+func TestUnresolvedRefsMissingRoot(t *testing.T) {
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		return nil, false
+	}
+	var unresolved []string
+	resolved, err := PopulateRefsWithOptions(schemaOf, "#/definitions/Root", Options{Unresolved: &unresolved})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(unresolved, []string{"#/definitions/Root"}) {
+		t.Errorf("expected the root ref to be reported unresolved, got %v", unresolved)
+	}
+	if resolved.Ref.GetURL() == nil {
+		t.Error("expected the root ref to be left in place")
+	}
+}
+
+func TestMaxNodes(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"first":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Big")}},
+					"second": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Big")}},
+				},
+			},
+		},
+		"Big": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"a": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"b": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"c": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefsWithOptions(schemaOf, "Root", Options{MaxNodes: 3})
+	if !errors.Is(err, ErrSchemaTooLarge) {
+		t.Errorf("expected ErrSchemaTooLarge, got %v", err)
+	}
+	if resolved != nil {
+		t.Errorf("expected no partial schema when the budget trips, got %v", resolved)
+	}
+
+	if _, err := PopulateRefsWithOptions(schemaOf, "Root", Options{MaxNodes: 100}); err != nil {
+		t.Errorf("unexpected error under a generous budget: %v", err)
+	}
+
+	// the zero value remains unbounded
+	if _, err := PopulateRefs(schemaOf, "Root"); err != nil {
+		t.Errorf("unexpected error without a budget: %v", err)
+	}
+}
+
+func TestShareIdenticalSubtrees(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"first":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("List")}},
+				"second": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("List")}},
+			},
+		}},
+		"List": {SchemaProps: spec.SchemaProps{
+			Type:  []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Leaf")}}},
+		}},
+		"Leaf": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	shared, err := PopulateRefsWithOptions(schemaOf, "Root", Options{ShareIdenticalSubtrees: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// both occurrences of List alias one resolved copy, so the nested
+	// pointers are identical.
+	if shared.Properties["first"].Items != shared.Properties["second"].Items {
+		t.Error("expected both occurrences of the ref to share one resolved subtree")
+	}
+
+	separate, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if separate.Properties["first"].Items == separate.Properties["second"].Items {
+		t.Error("expected independent copies without the option")
+	}
+	// shared output still matches the independent expansion
+	if !reflect.DeepEqual(shared, separate) {
+		t.Error("expected sharing not to change the resolved schema")
+	}
+}
+
+func TestUseEmbeddedDefinitions(t *testing.T) {
+	root := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"address": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Address")}},
+		},
+		Definitions: spec.Definitions{
+			"Address": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"country": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Country")}},
+				},
+			}},
+			"Country": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		},
+	}}
+	// schemaOf only knows the root; everything else is self-contained.
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "Root" {
+			return root, true
+		}
+		return nil, false
+	}
+
+	resolved, err := PopulateRefsWithOptions(schemaOf, "Root", Options{UseEmbeddedDefinitions: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	address := resolved.Properties["address"]
+	if address.Ref.GetURL() != nil {
+		t.Fatal("expected the local definition ref to resolve")
+	}
+	country, ok := address.Properties["country"]
+	if !ok {
+		t.Fatal("expected the substituted definition's properties")
+	}
+	if country.Ref.GetURL() != nil || country.Type[0] != "string" {
+		t.Errorf("expected the ref inside the local definition to resolve too, got %v", country)
+	}
+
+	// without the option the local ref stays unresolvable
+	if _, err := PopulateRefs(schemaOf, "Root"); err == nil {
+		t.Error("expected an error without the option")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sort"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// supportsParallel reports whether the options are free of state that must
+// be accumulated across the whole expansion, which the parallel path cannot
+// maintain coherently across workers. Options that inspect a node together
+// with its properties are also excluded: the parallel path expands the root
+// with its properties stripped, so they would never see the root node whole.
+func supportsParallel(opts Options) bool {
+	return opts.MaxResolvedBytes == 0 &&
+		opts.MaxNodes == 0 &&
+		opts.UnexpandedRefs == nil &&
+		opts.Unresolved == nil &&
+		opts.ResolutionPaths == nil &&
+		opts.Metrics == nil &&
+		opts.Allocator == nil &&
+		!opts.ShareIdenticalSubtrees &&
+		opts.Trace == nil &&
+		opts.MaxPropertiesPerObject == 0 &&
+		!opts.CheckCELReservedNames &&
+		!opts.ValidateRequiredReadOnly &&
+		!opts.InferImplicitTypes
+}
+
+// populateRefsParallel expands the root schema's property subtrees across a
+// bounded worker pool, each worker running an independent populator, and
+// merges the results into the parent deterministically. Everything on the
+// root node other than its properties is expanded serially first. The
+// original schemas are never mutated; unlike the serial path, a copy is
+// returned even when nothing changed.
+func populateRefsParallel(schemaOf func(ref string) (*spec.Schema, bool), rootRef string, rootSchema *spec.Schema, opts Options) (*spec.Schema, error) {
+	trunk := *rootSchema
+	trunk.Properties = nil
+	p := &populator{schemaOf: schemaOf, visited: sets.New(rootRef), opts: opts, origin: rootRef, refChain: []string{rootRef}}
+	resolvedTrunk, err := p.populateRefs(&trunk, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(p.errs) > 0 {
+		return nil, utilerrors.NewAggregate(p.errs)
+	}
+	result := *resolvedTrunk
+
+	names := make([]string, 0, len(rootSchema.Properties))
+	for name := range rootSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolved := make([]*spec.Schema, len(names))
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, opts.Parallelism)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			prop := rootSchema.Properties[name]
+			wp := &populator{schemaOf: schemaOf, visited: sets.New(rootRef), opts: opts, origin: rootRef, refChain: []string{rootRef}}
+			s, err := wp.populateRefs(&prop, name)
+			if err == nil && len(wp.errs) > 0 {
+				err = utilerrors.NewAggregate(wp.errs)
+			}
+			resolved[i], errs[i] = s, err
+		}(i, name)
+	}
+	wg.Wait()
+
+	var failures []error
+	props := make(map[string]spec.Schema, len(names))
+	for i, name := range names {
+		if errs[i] != nil {
+			failures = append(failures, errs[i])
+			continue
+		}
+		props[name] = *resolved[i]
+	}
+	if len(failures) > 0 {
+		return nil, utilerrors.NewAggregate(failures)
+	}
+	if len(props) > 0 {
+		result.Properties = props
+	}
+	return &result, nil
+}
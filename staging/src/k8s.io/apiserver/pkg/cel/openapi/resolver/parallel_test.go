@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// wideTestSchemas builds a root with the given number of properties, each a
+// ref into a small chain of definitions.
+func wideTestSchemas(width int) map[string]*spec.Schema {
+	schemas := map[string]*spec.Schema{
+		"Leaf": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		"Mid": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"leaf": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Leaf")}},
+			},
+		}},
+	}
+	props := make(map[string]spec.Schema, width)
+	for i := 0; i < width; i++ {
+		props[fmt.Sprintf("field%03d", i)] = spec.Schema{SchemaProps: spec.SchemaProps{
+			Ref: spec.MustCreateRef("Mid"),
+		}}
+	}
+	schemas["Root"] = &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: props,
+	}}
+	return schemas
+}
+
+func TestPopulateRefsParallelMatchesSerial(t *testing.T) {
+	schemas := wideTestSchemas(32)
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	serial, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parallel, err := PopulateRefsWithOptions(schemaOf, "Root", Options{Parallelism: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("expected the parallel expansion to match the serial one")
+	}
+	// originals are untouched
+	field := schemas["Root"].Properties["field000"]
+	if field.Ref.GetURL() == nil {
+		t.Error("original property ref was mutated")
+	}
+}
+
+func TestPopulateRefsParallelPropagatesErrors(t *testing.T) {
+	schemas := wideTestSchemas(8)
+	broken := schemas["Root"].Properties["field003"]
+	broken.Ref = spec.MustCreateRef("Missing")
+	schemas["Root"].Properties["field003"] = broken
+
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	_, err := PopulateRefsWithOptions(schemaOf, "Root", Options{Parallelism: 4})
+	if !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("expected the worker's miss to propagate, got %v", err)
+	}
+}
+
+// TestPopulateRefsParallelRootNodeOptionsParity covers options that inspect a
+// node together with its properties: the parallel path strips the root's
+// properties before expanding the trunk, so these must behave exactly as they
+// do serially, root node included.
+func TestPopulateRefsParallelRootNodeOptionsParity(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    Options
+		mutate  func(root *spec.Schema)
+		wantErr bool
+	}{
+		{
+			name:    "MaxPropertiesPerObject counts root properties",
+			opts:    Options{MaxPropertiesPerObject: 5},
+			wantErr: true,
+		},
+		{
+			name: "CheckCELReservedNames sees root properties",
+			opts: Options{CheckCELReservedNames: true},
+			mutate: func(root *spec.Schema) {
+				root.Properties["true"] = spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "ValidateRequiredReadOnly sees root properties",
+			opts: Options{ValidateRequiredReadOnly: true},
+			mutate: func(root *spec.Schema) {
+				root.Properties["id"] = spec.Schema{
+					SchemaProps:        spec.SchemaProps{Type: []string{"string"}},
+					SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true},
+				}
+				root.Required = []string{"id"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "InferImplicitTypes types the root node",
+			opts: Options{InferImplicitTypes: true},
+			mutate: func(root *spec.Schema) {
+				root.Type = nil
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schemas := wideTestSchemas(10)
+			if tc.mutate != nil {
+				tc.mutate(schemas["Root"])
+			}
+			schemaOf := func(ref string) (*spec.Schema, bool) {
+				s, ok := schemas[ref]
+				return s, ok
+			}
+			serial, serialErr := PopulateRefsWithOptions(schemaOf, "Root", tc.opts)
+			parallelOpts := tc.opts
+			parallelOpts.Parallelism = 4
+			parallel, parallelErr := PopulateRefsWithOptions(schemaOf, "Root", parallelOpts)
+			if tc.wantErr {
+				if serialErr == nil {
+					t.Fatal("expected the serial expansion to fail")
+				}
+				if parallelErr == nil {
+					t.Fatal("expected the parallel expansion to fail like the serial one")
+				}
+				return
+			}
+			if serialErr != nil || parallelErr != nil {
+				t.Fatalf("unexpected errors: serial=%v parallel=%v", serialErr, parallelErr)
+			}
+			if !reflect.DeepEqual(serial, parallel) {
+				t.Error("expected the parallel expansion to match the serial one")
+			}
+		})
+	}
+}
+
+func benchmarkPopulateRefsWide(b *testing.B, opts Options) {
+	schemas := wideTestSchemas(256)
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := PopulateRefsWithOptions(schemaOf, "Root", opts); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkPopulateRefsSerial(b *testing.B) {
+	benchmarkPopulateRefsWide(b, Options{})
+}
+
+func BenchmarkPopulateRefsParallel(b *testing.B) {
+	benchmarkPopulateRefsWide(b, Options{Parallelism: 8})
+}
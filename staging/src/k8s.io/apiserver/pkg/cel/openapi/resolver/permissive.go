@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// permissiveResolver falls back to a fully-open object schema when the
+// delegate does not know a GVK, so that policies can still be written against
+// unregistered types in permissive environments.
+type permissiveResolver struct {
+	delegate SchemaResolver
+}
+
+var _ SchemaResolver = (*permissiveResolver)(nil)
+
+// NewPermissiveResolver returns a SchemaResolver that resolves through the
+// delegate and, instead of failing with ErrSchemaNotFound, returns an open
+// object schema preserving unknown fields. Errors other than not-found pass
+// through unchanged.
+func NewPermissiveResolver(delegate SchemaResolver) SchemaResolver {
+	return &permissiveResolver{delegate: delegate}
+}
+
+func (r *permissiveResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		if errors.Is(err, ErrSchemaNotFound) {
+			return openSchema(), nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// openSchema returns a fresh object schema accepting arbitrary fields.
+func openSchema() *spec.Schema {
+	return &spec.Schema{
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+			extPreserveUnknownFields: true,
+		}},
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			AdditionalProperties: &spec.SchemaOrBool{
+				Allows: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestPermissiveResolver(t *testing.T) {
+	known := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	delegate := mapResolver{
+		known: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+		}},
+	}
+	r := NewPermissiveResolver(delegate)
+
+	s, err := r.ResolveSchema(known)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s.Properties["spec"]; !ok {
+		t.Error("expected the known GVK to resolve through the delegate")
+	}
+
+	unknown := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Unregistered"}
+	open, err := r.ResolveSchema(unknown)
+	if err != nil {
+		t.Fatalf("unexpected error for an unknown GVK: %v", err)
+	}
+	if !open.Type.Contains("object") {
+		t.Errorf("expected an open object fallback, got %v", open.Type)
+	}
+	if open.AdditionalProperties == nil || !open.AdditionalProperties.Allows {
+		t.Error("expected the fallback to allow additional properties")
+	}
+	if preserve, _ := open.Extensions.GetBool(extPreserveUnknownFields); !preserve {
+		t.Error("expected the fallback to preserve unknown fields")
+	}
+}
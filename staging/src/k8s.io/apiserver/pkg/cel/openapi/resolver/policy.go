@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// SchemaPolicy configures the constructs a PolicyValidatingResolver forbids.
+type SchemaPolicy struct {
+	// ForbidRootAdditionalProperties rejects schemas whose root allows
+	// arbitrary additional properties.
+	ForbidRootAdditionalProperties bool
+	// ForbidRootPreserveUnknownFields rejects schemas whose root carries
+	// x-kubernetes-preserve-unknown-fields.
+	ForbidRootPreserveUnknownFields bool
+	// ForbidPatternProperties rejects schemas using patternProperties at any
+	// depth.
+	ForbidPatternProperties bool
+}
+
+// PolicyViolationError reports the constructs of a resolved schema forbidden
+// by the configured SchemaPolicy.
+type PolicyViolationError struct {
+	// GVK is the kind whose schema violated the policy.
+	GVK schema.GroupVersionKind
+	// Violations describes each forbidden construct with its path.
+	Violations []string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("schema of %v violates policy: %s", e.GVK, strings.Join(e.Violations, "; "))
+}
+
+// policyValidatingResolver validates every resolved schema against a
+// SchemaPolicy, rejecting schemas using forbidden constructs before they are
+// served.
+type policyValidatingResolver struct {
+	delegate SchemaResolver
+	policy   SchemaPolicy
+}
+
+var _ SchemaResolver = (*policyValidatingResolver)(nil)
+
+// NewPolicyValidatingResolver returns a SchemaResolver that resolves through
+// the delegate and fails with a PolicyViolationError if the resolved schema
+// uses a construct forbidden by the policy.
+func NewPolicyValidatingResolver(delegate SchemaResolver, policy SchemaPolicy) SchemaResolver {
+	return &policyValidatingResolver{delegate: delegate, policy: policy}
+}
+
+func (r *policyValidatingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if violations := r.policy.check(s); len(violations) > 0 {
+		return nil, &PolicyViolationError{GVK: gvk, Violations: violations}
+	}
+	return s, nil
+}
+
+// check returns the policy violations of a resolved schema.
+func (p SchemaPolicy) check(root *spec.Schema) []string {
+	var violations []string
+	if p.ForbidRootAdditionalProperties && root.AdditionalProperties != nil && root.AdditionalProperties.Allows {
+		violations = append(violations, "additionalProperties is forbidden at the root")
+	}
+	if p.ForbidRootPreserveUnknownFields {
+		if preserve, _ := root.Extensions.GetBool(extPreserveUnknownFields); preserve {
+			violations = append(violations, fmt.Sprintf("%s is forbidden at the root", extPreserveUnknownFields))
+		}
+	}
+	if p.ForbidPatternProperties {
+		violations = append(violations, findPatternProperties(root, "")...)
+	}
+	return violations
+}
+
+// findPatternProperties reports every node of the schema using
+// patternProperties.
+func findPatternProperties(s *spec.Schema, path string) []string {
+	var violations []string
+	if len(s.PatternProperties) > 0 {
+		violations = append(violations, fmt.Sprintf("patternProperties is forbidden (at %s)", displayPath(path)))
+	}
+	for _, name := range sets.List(sets.KeySet(s.Properties)) {
+		prop := s.Properties[name]
+		violations = append(violations, findPatternProperties(&prop, childPath(path, name))...)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		violations = append(violations, findPatternProperties(s.AdditionalProperties.Schema, path+"[*]")...)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		violations = append(violations, findPatternProperties(s.Items.Schema, path+"[*]")...)
+	}
+	return violations
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestPolicyValidatingResolver(t *testing.T) {
+	compliantGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	violatingGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+	delegate := mapResolver{
+		compliantGVK: {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+		}},
+		violatingGVK: {
+			VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+				extPreserveUnknownFields: true,
+			}},
+			SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+		},
+	}
+	r := NewPolicyValidatingResolver(delegate, SchemaPolicy{
+		ForbidRootPreserveUnknownFields: true,
+		ForbidPatternProperties:         true,
+	})
+
+	if _, err := r.ResolveSchema(compliantGVK); err != nil {
+		t.Errorf("unexpected error for a compliant schema: %v", err)
+	}
+
+	_, err := r.ResolveSchema(violatingGVK)
+	if err == nil {
+		t.Fatal("expected a policy violation, got none")
+	}
+	violation := &PolicyViolationError{}
+	if !errors.As(err, &violation) {
+		t.Fatalf("expected PolicyViolationError, got %T: %v", err, err)
+	}
+	if violation.GVK != violatingGVK {
+		t.Errorf("expected the error to carry the GVK, got %v", violation.GVK)
+	}
+	if !strings.Contains(err.Error(), extPreserveUnknownFields) {
+		t.Errorf("expected the violation to name the forbidden construct, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// extensionPruningResolver removes properties marked with a given boolean
+// extension from resolved schemas, e.g. fields annotated "x-acme-internal:
+// true" that must not be exposed to tenant CEL policies.
+type extensionPruningResolver struct {
+	delegate     SchemaResolver
+	extensionKey string
+}
+
+var _ SchemaResolver = (*extensionPruningResolver)(nil)
+
+// NewExtensionPruningResolver returns a SchemaResolver that resolves via the
+// delegate and then drops every property whose schema carries the given
+// extension set to true. Pruned properties are also removed from required
+// lists. Pruning works on copies; the delegate's schemas are not modified.
+func NewExtensionPruningResolver(delegate SchemaResolver, extensionKey string) SchemaResolver {
+	return &extensionPruningResolver{delegate: delegate, extensionKey: extensionKey}
+}
+
+func (r *extensionPruningResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	pruned, _ := r.prune(s)
+	return pruned, nil
+}
+
+// prune removes marked properties from the schema and its children, copying
+// only what changes.
+func (r *extensionPruningResolver) prune(s *spec.Schema) (*spec.Schema, bool) {
+	result := *s
+	changed := false
+	if len(result.Properties) > 0 {
+		props := make(map[string]spec.Schema, len(result.Properties))
+		removed := sets.New[string]()
+		propsChanged := false
+		for name, prop := range result.Properties {
+			if marked, ok := prop.Extensions.GetBool(r.extensionKey); ok && marked {
+				removed.Insert(name)
+				propsChanged = true
+				continue
+			}
+			child, childChanged := r.prune(&prop)
+			if childChanged {
+				propsChanged = true
+			}
+			props[name] = *child
+		}
+		if propsChanged {
+			changed = true
+			result.Properties = props
+		}
+		if removed.Len() > 0 && len(result.Required) > 0 {
+			required := make([]string, 0, len(result.Required))
+			for _, name := range result.Required {
+				if !removed.Has(name) {
+					required = append(required, name)
+				}
+			}
+			if len(required) != len(result.Required) {
+				changed = true
+				result.Required = required
+			}
+		}
+	}
+	if result.Items != nil && result.Items.Schema != nil {
+		child, childChanged := r.prune(result.Items.Schema)
+		if childChanged {
+			changed = true
+			newItems := *result.Items
+			newItems.Schema = child
+			result.Items = &newItems
+		}
+	}
+	if result.AdditionalProperties != nil && result.AdditionalProperties.Schema != nil {
+		child, childChanged := r.prune(result.AdditionalProperties.Schema)
+		if childChanged {
+			changed = true
+			newProps := *result.AdditionalProperties
+			newProps.Schema = child
+			result.AdditionalProperties = &newProps
+		}
+	}
+	if changed {
+		return &result, true
+	}
+	return s, false
+}
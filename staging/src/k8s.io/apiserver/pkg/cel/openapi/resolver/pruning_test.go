@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestExtensionPruningResolver(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	original := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type:     []string{"object"},
+					Required: []string{"replicas", "internalToken"},
+					Properties: map[string]spec.Schema{
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+						"internalToken": {
+							SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+							VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+								"x-acme-internal": true,
+							}},
+						},
+					},
+				}},
+			},
+		},
+	}
+	r := NewExtensionPruningResolver(&fakeResolver{schema: original}, "x-acme-internal")
+
+	result, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resultSpec := result.Properties["spec"]
+	if _, ok := resultSpec.Properties["internalToken"]; ok {
+		t.Error("expected the internal property to be pruned")
+	}
+	if _, ok := resultSpec.Properties["replicas"]; !ok {
+		t.Error("expected unmarked properties to be preserved")
+	}
+	if !reflect.DeepEqual(resultSpec.Required, []string{"replicas"}) {
+		t.Errorf("expected pruned property to be removed from required, got %v", resultSpec.Required)
+	}
+	// the delegate's schema is untouched
+	originalSpec := original.Properties["spec"]
+	if _, ok := originalSpec.Properties["internalToken"]; !ok {
+		t.Error("delegate schema was mutated by pruning")
+	}
+	if !reflect.DeepEqual(originalSpec.Required, []string{"replicas", "internalToken"}) {
+		t.Errorf("delegate required list was mutated, got %v", originalSpec.Required)
+	}
+}
+
+func TestExtensionPruningResolverNoMarks(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	original := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		},
+	}}
+	r := NewExtensionPruningResolver(&fakeResolver{schema: original}, "x-acme-internal")
+	result, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != original {
+		t.Error("expected the original schema back when nothing is pruned")
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// ReadinessResolver wraps another SchemaResolver and tracks whether a set of
+// critical GVKs has been resolved successfully. It is intended to back health
+// checks that should report not-ready until the schemas a component depends
+// on are available.
+type ReadinessResolver struct {
+	delegate SchemaResolver
+
+	mu      sync.Mutex
+	pending sets.Set[schema.GroupVersionKind]
+}
+
+var _ SchemaResolver = (*ReadinessResolver)(nil)
+
+// NewReadinessResolver returns a ReadinessResolver that delegates to the given
+// resolver and becomes Ready once every critical GVK has resolved successfully
+// at least once.
+func NewReadinessResolver(delegate SchemaResolver, critical []schema.GroupVersionKind) *ReadinessResolver {
+	return &ReadinessResolver{
+		delegate: delegate,
+		pending:  sets.New[schema.GroupVersionKind](critical...),
+	}
+}
+
+// ResolveSchema resolves the schema via the delegate and records a successful
+// resolution of a critical GVK.
+func (r *ReadinessResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending.Delete(gvk)
+	return s, nil
+}
+
+// Ready reports whether all critical GVKs have resolved successfully at least
+// once. Once true, it never flips back to false.
+func (r *ReadinessResolver) Ready() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pending.Len() == 0
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// mapResolver resolves GVKs from a fixed map and returns ErrSchemaNotFound
+// for anything else.
+type mapResolver map[schema.GroupVersionKind]*spec.Schema
+
+func (m mapResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, ok := m[gvk]
+	if !ok {
+		return nil, fmt.Errorf("cannot resolve %v: %w", gvk, ErrSchemaNotFound)
+	}
+	return s, nil
+}
+
+func TestReadinessResolver(t *testing.T) {
+	foo := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	bar := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Bar"}
+	objectSchema := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+
+	delegate := mapResolver{foo: objectSchema}
+	r := NewReadinessResolver(delegate, []schema.GroupVersionKind{foo, bar})
+	if r.Ready() {
+		t.Error("expected not ready before any resolution")
+	}
+
+	// resolving one critical GVK is not enough
+	if _, err := r.ResolveSchema(foo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Ready() {
+		t.Error("expected not ready with a critical GVK still unresolved")
+	}
+
+	// a failed resolution does not count
+	if _, err := r.ResolveSchema(bar); err == nil {
+		t.Fatal("expected error for bar before it is served")
+	}
+	if r.Ready() {
+		t.Error("expected not ready after a failed resolution")
+	}
+
+	// once all critical GVKs resolve successfully, readiness flips
+	delegate[bar] = objectSchema
+	if _, err := r.ResolveSchema(bar); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.Ready() {
+		t.Error("expected ready after all critical GVKs resolved")
+	}
+}
+
+func TestReadinessResolverNoCriticalGVKs(t *testing.T) {
+	r := NewReadinessResolver(mapResolver{}, nil)
+	if !r.Ready() {
+		t.Error("expected ready when there are no critical GVKs")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// DetectUnboundedRecursion statically analyzes the unresolved schema graph
+// reachable from rootRef and reports the paths at which recursion has no base
+// case. A recursive reference is considered bounded if the cycle passes
+// through an optional property, a nullable node, array items or map values,
+// since each of those allows an instance to terminate. A cycle with none of
+// these breaks cannot be modeled as a finite CEL type.
+// schemaOf is the same callback PopulateRefs uses to look up refs.
+func DetectUnboundedRecursion(schemaOf func(ref string) (*spec.Schema, bool), rootRef string) []string {
+	d := &recursionDetector{schemaOf: schemaOf, onStack: map[string]int{}}
+	root, ok := schemaOf(rootRef)
+	if !ok {
+		return nil
+	}
+	d.onStack[rootRef] = 0
+	d.walk(root, "")
+	return d.unbounded
+}
+
+type recursionDetector struct {
+	schemaOf func(ref string) (*spec.Schema, bool)
+	// onStack maps each ref on the current walk stack to the number of
+	// boundedness breaks seen when it was entered.
+	onStack map[string]int
+	// breaks counts the boundedness breaks passed on the current walk path.
+	breaks    int
+	unbounded []string
+}
+
+func (d *recursionDetector) walk(s *spec.Schema, path string) {
+	if ref, isRef := refOf(s); isRef {
+		if atEntry, ok := d.onStack[ref]; ok {
+			if atEntry == d.breaks {
+				// no optional/nullable/array break since the cycle began
+				d.unbounded = append(d.unbounded, displayPath(path))
+			}
+			return
+		}
+		resolved, ok := d.schemaOf(ref)
+		if !ok {
+			return
+		}
+		d.onStack[ref] = d.breaks
+		d.walk(resolved, path)
+		delete(d.onStack, ref)
+		return
+	}
+	if s.Nullable {
+		d.breaks++
+		defer func() { d.breaks-- }()
+	}
+	required := sets.New[string](s.Required...)
+	for _, name := range sets.List(sets.KeySet(s.Properties)) {
+		prop := s.Properties[name]
+		optional := !required.Has(name)
+		if optional {
+			d.breaks++
+		}
+		d.walk(&prop, childPath(path, name))
+		if optional {
+			d.breaks--
+		}
+	}
+	for i := range s.AllOf {
+		d.walk(&s.AllOf[i], path)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		d.breaks++
+		d.walk(s.Items.Schema, path+"[*]")
+		d.breaks--
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		d.breaks++
+		d.walk(s.AdditionalProperties.Schema, path+"[*]")
+		d.breaks--
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestDetectUnboundedRecursionBoundedList(t *testing.T) {
+	// a tree node whose recursion goes through array items is bounded: the
+	// list can be empty
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Node": {
+			SchemaProps: spec.SchemaProps{
+				Type:     []string{"object"},
+				Required: []string{"value", "children"},
+				Properties: map[string]spec.Schema{
+					"value": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"children": {SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Node")},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	if got := DetectUnboundedRecursion(schemaOf, "#/definitions/Node"); len(got) != 0 {
+		t.Errorf("expected no unbounded recursion, got %v", got)
+	}
+}
+
+func TestDetectUnboundedRecursionOptionalBreak(t *testing.T) {
+	// recursion through an optional property is bounded: the chain can stop
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Link": {
+			SchemaProps: spec.SchemaProps{
+				Type:     []string{"object"},
+				Required: []string{"value"},
+				Properties: map[string]spec.Schema{
+					"value": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					"next":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Link")}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	if got := DetectUnboundedRecursion(schemaOf, "#/definitions/Link"); len(got) != 0 {
+		t.Errorf("expected no unbounded recursion, got %v", got)
+	}
+}
+
+func TestDetectUnboundedRecursionUnbounded(t *testing.T) {
+	// an unconditionally required self-reference has no base case
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Loop": {
+			SchemaProps: spec.SchemaProps{
+				Type:     []string{"object"},
+				Required: []string{"next"},
+				Properties: map[string]spec.Schema{
+					"next": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Loop")}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	got := DetectUnboundedRecursion(schemaOf, "#/definitions/Loop")
+	if len(got) != 1 {
+		t.Fatalf("expected one unbounded path, got %v", got)
+	}
+	if got[0] != "next" {
+		t.Errorf("expected the unbounded path to be %q, got %q", "next", got[0])
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// RefPopulator memoizes PopulateRefs across repeated calls against the same
+// schema set, so callers that expand the same root once per incoming object
+// pay the resolution cost only once per ref. It is safe for concurrent use
+// by multiple goroutines.
+type RefPopulator struct {
+	// Metrics, if set before first use, receives a cache-hit observation for
+	// every Populate call served from the cache, and the usual resolution
+	// observations for cache misses.
+	Metrics ResolutionMetrics
+
+	schemaOf func(ref string) (*spec.Schema, bool)
+
+	mu    sync.RWMutex
+	cache map[string]*spec.Schema
+}
+
+// NewRefPopulator creates a RefPopulator backed by the given schemaOf
+// callback.
+func NewRefPopulator(schemaOf func(ref string) (*spec.Schema, bool)) *RefPopulator {
+	return &RefPopulator{
+		schemaOf: schemaOf,
+		cache:    make(map[string]*spec.Schema),
+	}
+}
+
+// Populate returns the fully resolved expansion of root, computing it with
+// PopulateRefs on the first call and serving it from the cache afterwards.
+// Errors are not cached; a failed expansion is retried on the next call.
+// The returned schema is a shallow copy; callers must not mutate the nested
+// maps and slices it shares with the cache.
+func (p *RefPopulator) Populate(root string) (*spec.Schema, error) {
+	p.mu.RLock()
+	cached, ok := p.cache[root]
+	p.mu.RUnlock()
+	if ok {
+		if p.Metrics != nil {
+			p.Metrics.ObserveCacheHit()
+		}
+		s := *cached
+		return &s, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// another goroutine may have populated the entry while we waited.
+	if cached, ok := p.cache[root]; ok {
+		if p.Metrics != nil {
+			p.Metrics.ObserveCacheHit()
+		}
+		s := *cached
+		return &s, nil
+	}
+	resolved, err := PopulateRefsWithOptions(p.schemaOf, root, Options{Metrics: p.Metrics})
+	if err != nil {
+		return nil, err
+	}
+	p.cache[root] = resolved
+	s := *resolved
+	return &s, nil
+}
+
+// Invalidate drops the cached expansion of the given ref, if any, so the
+// next Populate recomputes it. Use it when a single definition in the
+// underlying schema set changes.
+func (p *RefPopulator) Invalidate(ref string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.cache, ref)
+}
+
+// Reset drops every cached expansion. Use it when the underlying schema set
+// is replaced wholesale.
+func (p *RefPopulator) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache = make(map[string]*spec.Schema)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestRefPopulatorMemoizes(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+				},
+			},
+		},
+		"Spec": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	calls := 0
+	populator := NewRefPopulator(func(ref string) (*spec.Schema, bool) {
+		calls++
+		s, ok := schemas[ref]
+		return s, ok
+	})
+
+	first, err := populator.Populate("Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	callsAfterFirst := calls
+	second, err := populator.Populate("Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != callsAfterFirst {
+		t.Errorf("expected the second Populate to be served from cache, got %d extra lookups", calls-callsAfterFirst)
+	}
+	if len(first.Properties) != len(second.Properties) {
+		t.Errorf("expected identical expansions, got %v and %v", first, second)
+	}
+
+	populator.Invalidate("Root")
+	if _, err := populator.Populate("Root"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls == callsAfterFirst {
+		t.Error("expected Invalidate to force a recomputation")
+	}
+
+	callsBeforeReset := calls
+	populator.Reset()
+	if _, err := populator.Populate("Root"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls == callsBeforeReset {
+		t.Error("expected Reset to force a recomputation")
+	}
+}
+
+func TestRefPopulatorDoesNotCacheErrors(t *testing.T) {
+	schemas := map[string]*spec.Schema{}
+	populator := NewRefPopulator(func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	})
+	if _, err := populator.Populate("Root"); err == nil {
+		t.Fatal("expected an error for the missing root")
+	}
+	schemas["Root"] = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	if _, err := populator.Populate("Root"); err != nil {
+		t.Errorf("expected the retry to succeed once the definition was added, got %v", err)
+	}
+}
+
+func TestRefPopulatorConcurrent(t *testing.T) {
+	populator := NewRefPopulator(func(ref string) (*spec.Schema, bool) {
+		return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}, true
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := populator.Populate("Root"); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
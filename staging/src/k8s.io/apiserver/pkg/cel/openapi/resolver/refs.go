@@ -17,10 +17,16 @@ limitations under the License.
 package resolver
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"time"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	apiservercel "k8s.io/apiserver/pkg/cel"
 	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // PopulateRefs recursively replaces Refs in the schema with the referred one.
@@ -28,45 +34,385 @@ import (
 // This function will not mutate the original schema. If the schema needs to be
 // mutated, a copy will be returned, otherwise it returns the original schema.
 func PopulateRefs(schemaOf func(ref string) (*spec.Schema, bool), rootRef string) (*spec.Schema, error) {
-	visitedRefs := sets.New[string]()
+	return PopulateRefsWithOptions(schemaOf, rootRef, Options{})
+}
+
+// PopulateRefsWithOptions behaves like PopulateRefs, additionally applying the
+// given Options during resolution.
+func PopulateRefsWithOptions(schemaOf func(ref string) (*spec.Schema, bool), rootRef string, opts Options) (*spec.Schema, error) {
+	p := &populator{schemaOf: schemaOf, visited: sets.New[string](), opts: opts, origin: rootRef, refChain: []string{rootRef}}
+	if opts.Unresolved != nil {
+		p.unresolved = sets.New[string]()
+	}
+	if sharingApplies(opts) {
+		p.shared = map[string]*spec.Schema{}
+	}
+	if opts.Trace != nil {
+		if opts.Trace.RefVisits == nil {
+			opts.Trace.RefVisits = map[string]int{}
+		}
+		if opts.Trace.RefNodes == nil {
+			opts.Trace.RefNodes = map[string]int{}
+		}
+		p.traceRef = rootRef
+		defer func() {
+			opts.Trace.UniqueRefs = len(opts.Trace.RefVisits)
+		}()
+	}
+	if opts.Metrics != nil {
+		start := time.Now()
+		defer func() {
+			opts.Metrics.ObserveResolution(p.refs, p.nodes, time.Since(start))
+		}()
+	}
+	if opts.ResolutionPaths != nil {
+		opts.ResolutionPaths[displayPath("")] = []string{rootRef}
+	}
+	if opts.OnResolveRef != nil {
+		if err := opts.OnResolveRef(rootRef); err != nil {
+			return nil, fmt.Errorf("resolving Ref for root schema %q: %w", rootRef, err)
+		}
+	}
 	rootSchema, ok := schemaOf(rootRef)
-	visitedRefs.Insert(rootRef)
+	if ok && opts.Trace != nil {
+		opts.Trace.RefVisits[rootRef]++
+	}
+	if !ok && opts.ResolveAlias != nil {
+		alias, err := opts.ResolveAlias(rootRef)
+		if err != nil {
+			return nil, fmt.Errorf("resolving Ref for root schema %q: %w", rootRef, err)
+		}
+		if alias != "" {
+			rootSchema, ok = schemaOf(alias)
+		}
+	}
+	p.visited.Insert(rootRef)
+	if ok && opts.Metrics != nil {
+		p.refs++
+	}
+	if ok && rootSchema == nil {
+		return nil, fmt.Errorf("resolving Ref for root schema %q: %w", rootRef, ErrNilResolvedSchema)
+	}
 	if !ok {
-		return nil, fmt.Errorf("internal error: cannot resolve Ref for root schema %q: %w", rootRef, ErrSchemaNotFound)
+		if p.unresolved != nil {
+			p.unresolved.Insert(rootRef)
+			*opts.Unresolved = append(*opts.Unresolved, sets.List(p.unresolved)...)
+			// leave the root ref in place so a later pass can retry.
+			return &spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef(rootRef)}}, nil
+		}
+		return nil, fmt.Errorf("internal error: cannot resolve Ref for root schema %q: %w", rootRef, ErrRootNotFound)
+	}
+	if opts.UseEmbeddedDefinitions && len(rootSchema.Definitions) > 0 {
+		schemaOf = schemaOfWithDefinitions(schemaOf, rootSchema.Definitions)
+		p.schemaOf = schemaOf
+	}
+	if opts.Parallelism > 1 && supportsParallel(opts) {
+		if _, isRef := refOf(rootSchema); !isRef && len(rootSchema.Properties) > 0 {
+			return populateRefsParallel(schemaOf, rootRef, rootSchema, opts)
+		}
+	}
+	result, err := p.populateRefs(rootSchema, "")
+	if opts.UnexpandedRefs != nil {
+		*opts.UnexpandedRefs = p.unexpanded
+	}
+	if p.unresolved != nil {
+		*opts.Unresolved = append(*opts.Unresolved, sets.List(p.unresolved)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(p.errs) > 0 {
+		return nil, utilerrors.NewAggregate(p.errs)
 	}
-	return populateRefs(schemaOf, visitedRefs, rootSchema)
+	return result, nil
 }
 
-func populateRefs(schemaOf func(ref string) (*spec.Schema, bool), visited sets.Set[string], schema *spec.Schema) (*spec.Schema, error) {
-	result := *schema
+// populator carries the state of a single PopulateRefs invocation through the
+// recursion.
+type populator struct {
+	schemaOf func(ref string) (*spec.Schema, bool)
+	visited  sets.Set[string]
+	opts     Options
+	// origin is the ref of the definition the walk is currently inside, used
+	// to annotate leaves when AnnotateLeafOrigin is set.
+	origin string
+	// errs accumulates non-fatal validation errors that are aggregated and
+	// reported together once the walk completes.
+	errs []error
+	// size tracks the approximate serialized size of the result so far,
+	// maintained only when MaxResolvedBytes is set.
+	size int
+	// refChain is the stack of refs entered to reach the current node,
+	// starting with the root ref, maintained only when ResolutionPaths is
+	// set.
+	refChain []string
+	// depth is the number of ref expansions on the stack, and unexpanded
+	// counts the refs left in place because of MaxDepth.
+	depth      int
+	unexpanded int
+	// unresolved collects the refs schemaOf could not find, maintained only
+	// when Options.Unresolved is set.
+	unresolved sets.Set[string]
+	// nodes counts the schema nodes visited and refs the refs expanded,
+	// maintained only when MaxNodes, Metrics, or a node budget is set.
+	nodes int
+	refs  int
+	// budget, when positive, stops ref expansion (leaving refs collapsed)
+	// once the node count exceeds it, after at least one ref has expanded.
+	// Unlike MaxNodes this degrades the output instead of failing.
+	budget int
+	// shared, when non-nil, caches the resolved subtree of each ref so every
+	// occurrence reuses one copy. See Options.ShareIdenticalSubtrees.
+	shared map[string]*spec.Schema
+	// traceRef is the innermost ref being expanded, attributing node counts
+	// in the trace. Maintained only when Options.Trace is set.
+	traceRef string
+	// cycles counts the cycle placeholders substituted so far; a subtree is
+	// only shared when its expansion added none, since placeholders depend on
+	// where in the graph the expansion started.
+	cycles int
+}
+
+// alloc returns a fresh schema to hold a resolver-created copy, taken from
+// the configured allocator if any.
+func (p *populator) alloc() *spec.Schema {
+	if p.opts.Allocator != nil {
+		return p.opts.Allocator.Allocate()
+	}
+	return new(spec.Schema)
+}
+
+// free returns an unused resolver-created copy to the configured allocator.
+func (p *populator) free(s *spec.Schema) {
+	if p.opts.Allocator != nil {
+		p.opts.Allocator.Free(s)
+	}
+}
+
+func (p *populator) populateRefs(schema *spec.Schema, path string) (*spec.Schema, error) {
+	if p.opts.MaxNodes > 0 || p.opts.Metrics != nil || p.budget > 0 {
+		p.nodes++
+		if p.opts.MaxNodes > 0 && p.nodes > p.opts.MaxNodes {
+			return nil, fmt.Errorf("resolved schema exceeds the %d-node budget at %s: %w",
+				p.opts.MaxNodes, displayPath(path), ErrSchemaTooLarge)
+		}
+	}
+	if p.opts.Trace != nil {
+		// ref nodes are attributed below, once the innermost ref is known
+		if _, isRef := refOf(schema); !isRef {
+			p.opts.Trace.RefNodes[p.traceRef]++
+		}
+	}
+	if p.budget > 0 && len(schema.Extensions) > 0 {
+		// a node expanded by an earlier budgeted pass remembers the ref it
+		// came from; treat that ref as an ancestor so cycle detection spans
+		// resumes.
+		if origin, ok := schema.Extensions.GetString(extBudgetOrigin); ok && !p.visited.Has(origin) {
+			p.visited.Insert(origin)
+			defer p.visited.Delete(origin)
+		}
+	}
+	result := p.alloc()
+	*result = *schema
 	changed := false
+	// shareKey marks the subtree for sharing once fully expanded.
+	shareKey := ""
+	cyclesBefore := p.cycles
 
+	if p.opts.Dialect == DialectV2Strict && schema.Ref.GetURL() != nil {
+		if siblings := refSiblings(schema); len(siblings) > 0 {
+			p.errs = append(p.errs, fmt.Errorf("schema at %q uses $ref with sibling keywords %v, forbidden under the v2 dialect",
+				displayPath(path), siblings))
+		}
+	}
 	ref, isRef := refOf(schema)
 	if isRef {
-		if visited.Has(ref) {
+		if p.opts.ScalarRefs != nil {
+			if scalar, ok := p.opts.ScalarRefs[CanonicalizeRef(ref)]; ok {
+				p.free(result)
+				leaf := scalarLeafSchema(scalar)
+				// referencing-node annotations win, as for regular refs.
+				if schema.Description != "" {
+					leaf.Description = schema.Description
+				}
+				return leaf, nil
+			}
+		}
+		if p.opts.MaxDepth > 0 && p.depth >= p.opts.MaxDepth {
+			// over budget: leave the ref in place for a later pass
+			p.unexpanded++
+			p.free(result)
+			return schema, nil
+		}
+		p.depth++
+		defer func() {
+			p.depth--
+		}()
+		if p.opts.Trace != nil {
+			p.opts.Trace.RefVisits[ref]++
+			p.opts.Trace.RefNodes[ref]++
+			if p.depth > p.opts.Trace.MaxDepth {
+				p.opts.Trace.MaxDepth = p.depth
+			}
+			prevTraceRef := p.traceRef
+			p.traceRef = ref
+			defer func() {
+				p.traceRef = prevTraceRef
+			}()
+		}
+		if p.visited.Has(ref) {
+			if p.opts.FailOnCycle {
+				return nil, &CyclicRefError{Ref: ref, Path: displayPath(path)}
+			}
+			p.cycles++
 			return &spec.Schema{
 				// for circular ref, return an empty object as placeholder
 				SchemaProps: spec.SchemaProps{Type: []string{"object"}},
 			}, nil
 		}
-		visited.Insert(ref)
+		if p.budget > 0 && p.nodes > p.budget && p.refs > 0 {
+			// node budget spent: leave the ref collapsed for a later resume.
+			// at least one ref expands per pass so resumption always makes
+			// progress. the cycle check above runs first so a circular ref
+			// collapses to the placeholder instead of unrolling one level
+			// per resume forever.
+			p.unexpanded++
+			p.free(result)
+			return schema, nil
+		}
+		p.visited.Insert(ref)
 		// restore visited state at the end of the recursion.
 		defer func() {
-			visited.Delete(ref)
+			p.visited.Delete(ref)
 		}()
 		// replace the whole schema with the referred one.
-		resolved, ok := schemaOf(ref)
+		if p.opts.OnResolveRef != nil {
+			if err := p.opts.OnResolveRef(ref); err != nil {
+				return nil, fmt.Errorf("resolving Ref %q at %q: %w", ref, displayPath(path), err)
+			}
+		}
+		if p.shared != nil && !refAnnotated(schema) {
+			if cached, ok := p.shared[ref]; ok {
+				p.free(result)
+				return cached, nil
+			}
+			shareKey = ref
+		}
+		resolved, ok := p.schemaOf(ref)
+		if !ok && p.opts.ResolveAlias != nil {
+			alias, err := p.opts.ResolveAlias(ref)
+			if err != nil {
+				return nil, fmt.Errorf("resolving Ref %q at %q: %w", ref, displayPath(path), err)
+			}
+			if alias != "" {
+				resolved, ok = p.schemaOf(alias)
+			}
+		}
 		if !ok {
-			return nil, fmt.Errorf("internal error: cannot resolve Ref %q: %w", ref, ErrSchemaNotFound)
+			if p.unresolved != nil {
+				// record the miss and leave the ref in place for a later pass.
+				p.unresolved.Insert(ref)
+				p.free(result)
+				return schema, nil
+			}
+			return nil, &UnresolvedRefError{Ref: ref, Path: displayPath(path)}
+		}
+		if resolved == nil {
+			return nil, fmt.Errorf("resolving Ref %q at %q: %w", ref, displayPath(path), ErrNilResolvedSchema)
+		}
+		*result = *resolved
+		// the structural dialect allows annotations next to $ref; keep the
+		// referencing node's, which win over the target's own.
+		if schema.Description != "" {
+			result.Description = schema.Description
+		}
+		if schema.Title != "" {
+			result.Title = schema.Title
+		}
+		if schema.Default != nil {
+			result.Default = schema.Default
+		}
+		if schema.Example != nil {
+			result.Example = schema.Example
 		}
-		result = *resolved
 		changed = true
+		if p.budget > 0 {
+			// record which ref this subtree came from so a later resume can
+			// rebuild the ancestry for cycle detection; the markers are
+			// stripped once the expansion completes.
+			exts := make(spec.Extensions, len(result.Extensions)+1)
+			for k, v := range result.Extensions {
+				exts[k] = v
+			}
+			exts[extBudgetOrigin] = ref
+			result.Extensions = exts
+		}
+		if p.opts.Metrics != nil || p.budget > 0 {
+			p.refs++
+		}
+		if p.opts.ResolutionPaths != nil {
+			p.refChain = append(p.refChain, ref)
+			chain := make([]string, len(p.refChain))
+			copy(chain, p.refChain)
+			p.opts.ResolutionPaths[displayPath(path)] = chain
+			defer func() {
+				p.refChain = p.refChain[:len(p.refChain)-1]
+			}()
+		}
+		// children of the resolved schema originate from this definition.
+		prevOrigin := p.origin
+		p.origin = ref
+		defer func() {
+			p.origin = prevOrigin
+		}()
+	}
+	if p.opts.AnnotateLeafOrigin && len(p.origin) > 0 && isScalarLeaf(result) {
+		ext := make(spec.Extensions, len(result.Extensions)+1)
+		for k, v := range result.Extensions {
+			ext[k] = v
+		}
+		ext[extSchemaOrigin] = p.origin
+		result.Extensions = ext
+		changed = true
+	}
+	if p.opts.RejectUnknownKeywords && len(result.ExtraProps) > 0 {
+		keys := sets.List(sets.KeySet(result.ExtraProps))
+		return nil, fmt.Errorf("schema at %q carries unknown keywords %v", displayPath(path), keys)
+	}
+	if p.opts.RejectMetaSchemaKeywords {
+		p.errs = append(p.errs, checkMetaSchemaKeywords(result, path)...)
+	}
+	if p.opts.ValidateBoundOrdering {
+		p.errs = append(p.errs, checkBoundOrdering(result, path)...)
+	}
+	if p.opts.ValidateEnumFormat {
+		p.errs = append(p.errs, checkEnumFormat(result, path)...)
+	}
+	if p.opts.ValidateRequiredReadOnly {
+		p.errs = append(p.errs, checkRequiredReadOnly(result, path)...)
+	}
+	if p.opts.ValidateEnumTypes {
+		if err := checkEnumTypes(result, path); err != nil {
+			p.errs = append(p.errs, err)
+		}
+	}
+	if p.opts.CheckCELReservedNames {
+		p.errs = append(p.errs, checkCELReservedNames(result, path)...)
+	}
+	if p.opts.MaxResolvedBytes > 0 {
+		p.size += approxNodeSize(result)
+		if p.size > p.opts.MaxResolvedBytes {
+			return nil, &LimitExceededError{Path: displayPath(path), Limit: p.opts.MaxResolvedBytes, Actual: p.size, Unit: "bytes"}
+		}
+	}
+	if p.opts.MaxPropertiesPerObject > 0 && len(result.Properties) > p.opts.MaxPropertiesPerObject {
+		return nil, &LimitExceededError{Path: displayPath(path), Limit: p.opts.MaxPropertiesPerObject, Actual: len(result.Properties), Unit: "properties"}
 	}
 	// schema is an object, populate its properties and additionalProperties
 	props := make(map[string]spec.Schema, len(schema.Properties))
 	propsChanged := false
 	for name, prop := range result.Properties {
-		populated, err := populateRefs(schemaOf, visited, &prop)
+		populated, err := p.populateRefs(&prop, childPath(path, name))
 		if err != nil {
 			return nil, err
 		}
@@ -80,20 +426,73 @@ func populateRefs(schemaOf func(ref string) (*spec.Schema, bool), visited sets.S
 		result.Properties = props
 	}
 	if result.AdditionalProperties != nil && result.AdditionalProperties.Schema != nil {
-		populated, err := populateRefs(schemaOf, visited, result.AdditionalProperties.Schema)
+		_, wasRef := refOf(result.AdditionalProperties.Schema)
+		populated, err := p.populateRefs(result.AdditionalProperties.Schema, path+"[*]")
 		if err != nil {
 			return nil, err
 		}
-		if populated != result.AdditionalProperties.Schema {
+		switch {
+		case wasRef && isSchemaFalse(populated):
+			// the ref resolved to the boolean "false" schema; fold it into
+			// Allows instead of keeping a spurious schema.
+			changed = true
+			result.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+		case wasRef && isSchemaTrue(populated):
+			changed = true
+			result.AdditionalProperties = &spec.SchemaOrBool{Allows: true}
+		case populated != result.AdditionalProperties.Schema:
 			changed = true
 			newProps := *result.AdditionalProperties
 			newProps.Schema = populated
 			result.AdditionalProperties = &newProps
 		}
 	}
+	if len(result.PatternProperties) > 0 {
+		// the regex keys are kept verbatim; only the schemas are populated.
+		patternProps := make(map[string]spec.Schema, len(result.PatternProperties))
+		patternChanged := false
+		for pattern, prop := range result.PatternProperties {
+			populated, err := p.populateRefs(&prop, childPath(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			if populated != &prop {
+				patternChanged = true
+			}
+			patternProps[pattern] = *populated
+		}
+		if patternChanged {
+			changed = true
+			result.PatternProperties = patternProps
+		}
+	}
+	if len(result.Dependencies) > 0 {
+		// only schema-form dependencies carry refs; property lists pass through.
+		deps := make(spec.Dependencies, len(result.Dependencies))
+		depsChanged := false
+		for name, dep := range result.Dependencies {
+			if dep.Schema == nil {
+				deps[name] = dep
+				continue
+			}
+			populated, err := p.populateRefs(dep.Schema, childPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			if populated != dep.Schema {
+				depsChanged = true
+				dep.Schema = populated
+			}
+			deps[name] = dep
+		}
+		if depsChanged {
+			changed = true
+			result.Dependencies = deps
+		}
+	}
 	// schema is a list, populate its items
 	if result.Items != nil && result.Items.Schema != nil {
-		populated, err := populateRefs(schemaOf, visited, result.Items.Schema)
+		populated, err := p.populateRefs(result.Items.Schema, path+"[*]")
 		if err != nil {
 			return nil, err
 		}
@@ -104,12 +503,407 @@ func populateRefs(schemaOf func(ref string) (*spec.Schema, bool), visited sets.S
 			result.Items = &newItems
 		}
 	}
+	// tuple-style arrays carry a schema per position instead of a single one
+	if result.Items != nil && len(result.Items.Schemas) > 0 {
+		populated, sliceChanged, err := p.populateRefsSlice(result.Items.Schemas, path+"[*]")
+		if err != nil {
+			return nil, err
+		}
+		if sliceChanged {
+			changed = true
+			newItems := *result.Items
+			newItems.Schemas = populated
+			result.Items = &newItems
+		}
+	}
+	if p.opts.ExpandPreserveUnknownFields {
+		if preserve, _ := result.Extensions.GetBool(extPreserveUnknownFields); preserve {
+			if !result.Type.Contains("object") {
+				result.Type = []string{"object"}
+				changed = true
+			}
+			if result.AdditionalProperties == nil {
+				result.AdditionalProperties = &spec.SchemaOrBool{Allows: true}
+				changed = true
+			}
+		}
+	}
+	if p.opts.InferImplicitTypes && len(result.Type) == 0 {
+		if inferred := implicitType(result); len(inferred) > 0 {
+			result.Type = []string{inferred}
+			changed = true
+		}
+	}
+	// combinator branches may themselves carry refs. A ref wrapped in an
+	// allOf has already replaced the whole schema above; anything else is
+	// resolved element by element.
+	for _, combinator := range []*[]spec.Schema{&result.AllOf, &result.AnyOf, &result.OneOf} {
+		populated, sliceChanged, err := p.populateRefsSlice(*combinator, path)
+		if err != nil {
+			return nil, err
+		}
+		if sliceChanged {
+			changed = true
+			*combinator = populated
+		}
+	}
+	if result.Not != nil {
+		populated, err := p.populateRefs(result.Not, path)
+		if err != nil {
+			return nil, err
+		}
+		if populated != result.Not {
+			changed = true
+			result.Not = populated
+		}
+	}
+	if p.opts.MergeValidationRules && len(result.AllOf) > 0 && p.mergeAllOfValidations(result) {
+		changed = true
+	}
+	if p.opts.NormalizeEmptySlices && normalizeEmptySlices(result) {
+		changed = true
+	}
 	if changed {
-		return &result, nil
+		if shareKey != "" && p.cycles == cyclesBefore {
+			p.shared[shareKey] = result
+		}
+		return result, nil
 	}
+	p.free(result)
 	return schema, nil
 }
 
+// extSchemaOrigin names the extension carrying the definition a resolved
+// scalar leaf originates from.
+const extSchemaOrigin = "x-kubernetes-schema-origin"
+
+// implicitType returns the type implied by the structural keywords of an
+// untyped schema: "object" when it declares properties, "array" when it
+// declares only items, or "" when neither applies.
+func implicitType(s *spec.Schema) string {
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	if s.Items != nil && (s.Items.Schema != nil || len(s.Items.Schemas) > 0) {
+		return "array"
+	}
+	return ""
+}
+
+// extValidations names the extension carrying CEL validation rules.
+const extValidations = "x-kubernetes-validations"
+
+// populateRefsSlice resolves the refs of each element of a combinator slice,
+// returning a new slice only if an element changed.
+func (p *populator) populateRefsSlice(schemas []spec.Schema, path string) ([]spec.Schema, bool, error) {
+	changed := false
+	out := make([]spec.Schema, len(schemas))
+	for i := range schemas {
+		element := schemas[i]
+		populated, err := p.populateRefs(&element, path)
+		if err != nil {
+			return nil, false, err
+		}
+		if populated != &element {
+			changed = true
+		}
+		out[i] = *populated
+	}
+	if !changed {
+		return schemas, false, nil
+	}
+	return out, true, nil
+}
+
+// mergeAllOfValidations hoists the x-kubernetes-validations rules of the
+// schema's (already resolved) allOf branches onto the schema itself,
+// deduplicating identical rules. It reports whether the schema was modified.
+func (p *populator) mergeAllOfValidations(result *spec.Schema) bool {
+	var rules []interface{}
+	seen := sets.New[string]()
+	appendRules := func(v interface{}) {
+		list, ok := v.([]interface{})
+		if !ok {
+			return
+		}
+		for _, rule := range list {
+			b, err := json.Marshal(rule)
+			if err != nil || seen.Has(string(b)) {
+				continue
+			}
+			seen.Insert(string(b))
+			rules = append(rules, rule)
+		}
+	}
+	// the schema's own rules come first, followed by each branch's in order.
+	appendRules(result.Extensions[extValidations])
+	hoisted := false
+	branches := make([]spec.Schema, len(result.AllOf))
+	for i := range result.AllOf {
+		branchCopy := result.AllOf[i]
+		if v, ok := branchCopy.Extensions[extValidations]; ok {
+			appendRules(v)
+			ext := make(spec.Extensions, len(branchCopy.Extensions)-1)
+			for k, val := range branchCopy.Extensions {
+				if k != extValidations {
+					ext[k] = val
+				}
+			}
+			branchCopy.Extensions = ext
+			hoisted = true
+		}
+		branches[i] = branchCopy
+	}
+	if !hoisted {
+		return false
+	}
+	result.AllOf = branches
+	ext := make(spec.Extensions, len(result.Extensions)+1)
+	for k, v := range result.Extensions {
+		ext[k] = v
+	}
+	ext[extValidations] = rules
+	result.Extensions = ext
+	return true
+}
+
+// isScalarLeaf reports whether the schema is a typed scalar with no children.
+func isScalarLeaf(s *spec.Schema) bool {
+	return len(s.Type) > 0 && !s.Type.Contains("object") && !s.Type.Contains("array") &&
+		len(s.Properties) == 0 && s.Items == nil &&
+		(s.AdditionalProperties == nil || s.AdditionalProperties.Schema == nil)
+}
+
+// approxNodeSize estimates the serialized size of a single schema node by
+// marshaling it with its children stripped. Summing the estimate over every
+// visited node approximates the size of the whole resolved schema.
+func approxNodeSize(s *spec.Schema) int {
+	shallow := *s
+	shallow.Properties = nil
+	shallow.AdditionalProperties = nil
+	shallow.Items = nil
+	shallow.AllOf = nil
+	shallow.AnyOf = nil
+	shallow.OneOf = nil
+	shallow.Definitions = nil
+	b, err := json.Marshal(&shallow)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// refSiblings returns the keywords a schema declares next to its $ref, based
+// on the schema's JSON serialization.
+func refSiblings(s *spec.Schema) []string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	delete(m, "$ref")
+	return sets.List(sets.KeySet(m))
+}
+
+// checkBoundOrdering reports every paired bound on the schema whose minimum
+// exceeds its maximum.
+func checkBoundOrdering(s *spec.Schema, path string) []error {
+	var errs []error
+	if s.MinItems != nil && s.MaxItems != nil && *s.MinItems > *s.MaxItems {
+		errs = append(errs, fmt.Errorf("schema at %q has minItems %d greater than maxItems %d", displayPath(path), *s.MinItems, *s.MaxItems))
+	}
+	if s.MinLength != nil && s.MaxLength != nil && *s.MinLength > *s.MaxLength {
+		errs = append(errs, fmt.Errorf("schema at %q has minLength %d greater than maxLength %d", displayPath(path), *s.MinLength, *s.MaxLength))
+	}
+	if s.MinProperties != nil && s.MaxProperties != nil && *s.MinProperties > *s.MaxProperties {
+		errs = append(errs, fmt.Errorf("schema at %q has minProperties %d greater than maxProperties %d", displayPath(path), *s.MinProperties, *s.MaxProperties))
+	}
+	if s.Minimum != nil && s.Maximum != nil && *s.Minimum > *s.Maximum {
+		errs = append(errs, fmt.Errorf("schema at %q has minimum %v greater than maximum %v", displayPath(path), *s.Minimum, *s.Maximum))
+	}
+	return errs
+}
+
+// checkCELReservedNames reports property names that would need escaping to be
+// addressed from CEL, in particular names matching CEL reserved identifiers.
+func checkCELReservedNames(s *spec.Schema, path string) []error {
+	var errs []error
+	for _, name := range sets.List(sets.KeySet(s.Properties)) {
+		escaped, ok := apiservercel.Escape(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("property %q at %q cannot be represented as a CEL identifier", name, displayPath(path)))
+			continue
+		}
+		if escaped != name {
+			errs = append(errs, fmt.Errorf("property %q at %q collides with a CEL reserved identifier and requires escaping as %q", name, displayPath(path), escaped))
+		}
+	}
+	return errs
+}
+
+// checkEnumFormat reports enum values on a string node that do not parse as
+// the node's declared format. Unknown formats are skipped.
+func checkEnumFormat(s *spec.Schema, path string) []error {
+	if len(s.Enum) == 0 || len(s.Format) == 0 || !s.Type.Contains("string") {
+		return nil
+	}
+	if !strfmt.Default.ContainsName(s.Format) {
+		return nil
+	}
+	var errs []error
+	for _, v := range s.Enum {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if !strfmt.Default.Validates(s.Format, str) {
+			errs = append(errs, fmt.Errorf("enum value %q at %q is not a valid %s", str, displayPath(path), s.Format))
+		}
+	}
+	return errs
+}
+
+// checkMetaSchemaKeywords reports the meta-schema keywords the schema node
+// carries: id and $schema, which unmarshal into dedicated fields, and $id,
+// which is captured as an unknown field.
+func checkMetaSchemaKeywords(s *spec.Schema, path string) []error {
+	var errs []error
+	if len(s.ID) > 0 {
+		errs = append(errs, fmt.Errorf("schema at %q carries the forbidden keyword \"id\"", displayPath(path)))
+	}
+	if len(s.Schema) > 0 {
+		errs = append(errs, fmt.Errorf("schema at %q carries the forbidden keyword \"$schema\"", displayPath(path)))
+	}
+	if _, ok := s.ExtraProps["$id"]; ok {
+		errs = append(errs, fmt.Errorf("schema at %q carries the forbidden keyword \"$id\"", displayPath(path)))
+	}
+	return errs
+}
+
+// checkRequiredReadOnly reports every property of the schema that is both
+// required and readOnly, since such a field can neither be omitted nor set
+// on create.
+func checkRequiredReadOnly(s *spec.Schema, path string) []error {
+	var errs []error
+	for _, name := range s.Required {
+		prop, ok := s.Properties[name]
+		if !ok || !prop.ReadOnly {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("field %q at %q is both required and readOnly", name, displayPath(path)))
+	}
+	return errs
+}
+
+// checkEnumTypes reports the first enum value on the schema that is
+// inconsistent with the schema's declared type. Untyped nodes are skipped.
+func checkEnumTypes(s *spec.Schema, path string) error {
+	if len(s.Enum) == 0 || len(s.Type) == 0 {
+		return nil
+	}
+	for _, v := range s.Enum {
+		if matchesType(s.Type, v) {
+			continue
+		}
+		return fmt.Errorf("enum value %v at %q is not of type %v", v, displayPath(path), s.Type)
+	}
+	return nil
+}
+
+// matchesType reports whether an unmarshaled enum value is consistent with
+// one of the declared types.
+func matchesType(types spec.StringOrArray, v interface{}) bool {
+	for _, t := range types {
+		switch t {
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "integer":
+			// JSON numbers unmarshal as float64
+			if f, ok := v.(float64); ok && f == float64(int64(f)) {
+				return true
+			}
+			if _, ok := v.(int64); ok {
+				return true
+			}
+			if _, ok := v.(int); ok {
+				return true
+			}
+		case "number":
+			switch v.(type) {
+			case float64, int64, int:
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := v.(map[string]interface{}); ok {
+				return true
+			}
+		case "null":
+			if v == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeEmptySlices replaces empty, non-nil slices on the copied schema
+// with nil and reports whether anything changed.
+func normalizeEmptySlices(s *spec.Schema) bool {
+	changed := false
+	if s.Required != nil && len(s.Required) == 0 {
+		s.Required = nil
+		changed = true
+	}
+	if s.Enum != nil && len(s.Enum) == 0 {
+		s.Enum = nil
+		changed = true
+	}
+	if s.AllOf != nil && len(s.AllOf) == 0 {
+		s.AllOf = nil
+		changed = true
+	}
+	if s.AnyOf != nil && len(s.AnyOf) == 0 {
+		s.AnyOf = nil
+		changed = true
+	}
+	if s.OneOf != nil && len(s.OneOf) == 0 {
+		s.OneOf = nil
+		changed = true
+	}
+	return changed
+}
+
+// childPath joins the path of a parent schema with the name of one of its
+// properties.
+func childPath(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+// displayPath renders a field path for error messages, using "<root>" for the
+// root schema.
+func displayPath(path string) string {
+	if len(path) == 0 {
+		return "<root>"
+	}
+	return path
+}
+
 func refOf(schema *spec.Schema) (string, bool) {
 	if schema.Ref.GetURL() != nil {
 		return schema.Ref.String(), true
@@ -124,3 +918,93 @@ func refOf(schema *spec.Schema) (string, bool) {
 	}
 	return "", false
 }
+
+// schemaOfWithDefinitions extends schemaOf with a schema's embedded
+// Definitions map, consulted when the primary source misses. Definitions
+// substituted from the map go through the normal recursion, so their own
+// internal refs resolve as well.
+func schemaOfWithDefinitions(schemaOf func(ref string) (*spec.Schema, bool), defs spec.Definitions) func(ref string) (*spec.Schema, bool) {
+	return func(ref string) (*spec.Schema, bool) {
+		if s, ok := schemaOf(ref); ok {
+			return s, true
+		}
+		if def, ok := defs[CanonicalizeRef(ref)]; ok {
+			return &def, true
+		}
+		return nil, false
+	}
+}
+
+// isSchemaTrue reports the boolean "true" schema, which kube-openapi models
+// as a schema with no constraints at all.
+func isSchemaTrue(s *spec.Schema) bool {
+	return s == nil || reflect.DeepEqual(*s, spec.Schema{})
+}
+
+// isSchemaFalse reports the boolean "false" schema in its canonical
+// encoding, {"not": {}}.
+func isSchemaFalse(s *spec.Schema) bool {
+	if s == nil || s.Not == nil || !isSchemaTrue(s.Not) {
+		return false
+	}
+	rest := *s
+	rest.Not = nil
+	return isSchemaTrue(&rest)
+}
+
+// refAnnotated reports whether a referencing node carries annotations that
+// would be merged onto the referred schema, making its expansion unsharable.
+func refAnnotated(s *spec.Schema) bool {
+	return s.Description != "" || s.Title != "" || s.Default != nil || s.Example != nil
+}
+
+// sharingApplies reports whether subtree sharing is sound under the given
+// options: it is disabled together with any option whose output or
+// accounting depends on where a ref occurs.
+func sharingApplies(opts Options) bool {
+	return opts.ShareIdenticalSubtrees &&
+		opts.Allocator == nil &&
+		!opts.AnnotateLeafOrigin &&
+		opts.ResolutionPaths == nil &&
+		opts.Unresolved == nil &&
+		opts.UnexpandedRefs == nil &&
+		opts.MaxDepth <= 0 &&
+		opts.MaxNodes <= 0 &&
+		opts.Metrics == nil
+}
+
+// PopulateRefsWithErrors behaves like PopulateRefsWithOptions for a schemaOf
+// callback that can itself fail: a non-nil error aborts the expansion with
+// that error wrapped, while (nil, false, nil) still means the ref is simply
+// not found. This lets discovery-backed resolvers distinguish a transport
+// failure from a genuinely absent type instead of collapsing both into
+// not-found.
+func PopulateRefsWithErrors(schemaOf func(ref string) (*spec.Schema, bool, error), rootRef string, opts Options) (*spec.Schema, error) {
+	var lookupErr error
+	wrapped := func(ref string) (*spec.Schema, bool) {
+		if lookupErr != nil {
+			return nil, false
+		}
+		s, ok, err := schemaOf(ref)
+		if err != nil {
+			lookupErr = fmt.Errorf("resolving Ref %q: %w", ref, err)
+			return nil, false
+		}
+		return s, ok
+	}
+	result, err := PopulateRefsWithOptions(wrapped, rootRef, opts)
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	return result, err
+}
+
+// PopulateRefsInSchema expands the refs inside an already-loaded schema, such
+// as a CRD's openAPIV3Schema, against schemaOf, without requiring the root to
+// be registered under a ref of its own. Copy-on-write applies as in
+// PopulateRefs: the provided root is never mutated, and it is returned
+// unchanged when it contains no refs.
+func PopulateRefsInSchema(schemaOf func(ref string) (*spec.Schema, bool), root *spec.Schema) (*spec.Schema, error) {
+	result, _, err := populateBudgeted(schemaOf, root, "", 0)
+	return result, err
+}
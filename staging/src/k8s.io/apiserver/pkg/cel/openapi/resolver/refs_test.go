@@ -17,6 +17,8 @@ limitations under the License.
 package resolver
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"k8s.io/kube-openapi/pkg/validation/spec"
@@ -238,3 +240,602 @@ func TestPopulateRefs_DoesNotMutateNestedItems(t *testing.T) {
 		t.Errorf("original innerArraySchema.Items.Schema.Ref was mutated, got %v", innerArraySchema.Items.Schema.Ref.String())
 	}
 }
+
+func TestPopulateRefsTwoNodeCycle(t *testing.T) {
+	// Parent and Child reference each other: Parent.children -> Child,
+	// Child.owner -> Parent.
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Parent": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"children": {SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Child")},
+						}},
+					}},
+				},
+			},
+		},
+		"#/definitions/Child": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"owner": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Parent")}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "#/definitions/Parent")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the cycle is cut at Child.owner with an object placeholder
+	owner := resolved.Properties["children"].Items.Schema.Properties["owner"]
+	if !owner.Type.Contains("object") {
+		t.Errorf("expected an object placeholder where the cycle closes, got %v", owner.Type)
+	}
+	// the original schemas keep their refs unresolved
+	originalOwner := schemas["#/definitions/Child"].Properties["owner"]
+	if got := originalOwner.Ref.String(); got != "#/definitions/Parent" {
+		t.Errorf("original schema was mutated, owner ref is now %q", got)
+	}
+}
+
+func TestPopulateRefsThreeNodeCycle(t *testing.T) {
+	// A -> B (properties) -> C (additionalProperties) -> A (items)
+	schemas := map[string]*spec.Schema{
+		"#/definitions/A": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"b": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/B")}},
+				},
+			},
+		},
+		"#/definitions/B": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+					SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/C")},
+				}},
+			},
+		},
+		"#/definitions/C": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"array"},
+				Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+					SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/A")},
+				}},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "#/definitions/A")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the cycle is cut where A re-enters, under C's items
+	closing := resolved.Properties["b"].AdditionalProperties.Schema.Items.Schema
+	if !closing.Type.Contains("object") || len(closing.Properties) != 0 {
+		t.Errorf("expected an empty object placeholder where the cycle closes, got %+v", closing)
+	}
+	// the originals keep their refs unresolved
+	if got := schemas["#/definitions/C"].Items.Schema.Ref.String(); got != "#/definitions/A" {
+		t.Errorf("original schema was mutated, items ref is now %q", got)
+	}
+}
+
+func TestPopulateRefs_DoesNotMutateOriginalProperties(t *testing.T) {
+	templateSchema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"containers": {SchemaProps: spec.SchemaProps{Type: []string{"array"}}},
+			},
+		},
+	}
+	rootSchema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"template": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/PodTemplateSpec")}},
+				"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		},
+	}
+	originalTemplate := rootSchema.Properties["template"]
+
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root":            rootSchema,
+		"#/definitions/PodTemplateSpec": templateSchema,
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "#/definitions/Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the property-level ref is resolved in the output
+	if _, ok := resolved.Properties["template"].Properties["containers"]; !ok {
+		t.Error("expected the template property ref to be resolved")
+	}
+	// the output carries a fresh Properties map; the original map and its
+	// entries are untouched
+	if resolved == rootSchema {
+		t.Error("expected a copy to be returned when a property changed")
+	}
+	stillRef := rootSchema.Properties["template"]
+	if stillRef.Ref.GetURL() == nil {
+		t.Error("original Properties map was mutated")
+	}
+	if got := originalTemplate.Ref.String(); got != "#/definitions/PodTemplateSpec" {
+		t.Errorf("original template property ref was mutated, got %q", got)
+	}
+}
+
+func TestPopulateRefs_AllOfWrappedRef(t *testing.T) {
+	// kubebuilder wraps a ref in a single-element allOf to attach a
+	// description; the referred schema replaces the wrapper
+	wrapper := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Description: "the pod template",
+			AllOf: []spec.Schema{
+				{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/Template")}},
+			},
+		},
+	}
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Wrapper": wrapper,
+		"#/definitions/Template": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"containers": {SchemaProps: spec.SchemaProps{Type: []string{"array"}}},
+				},
+			},
+		},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "#/definitions/Wrapper")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resolved.Properties["containers"]; !ok {
+		t.Error("expected the allOf-wrapped ref to resolve to the underlying type")
+	}
+	// the original wrapper and its allOf slice are untouched
+	if len(wrapper.AllOf) != 1 || wrapper.AllOf[0].Ref.GetURL() == nil {
+		t.Errorf("original allOf slice was mutated: %+v", wrapper.AllOf)
+	}
+}
+
+func TestPopulateRefs_CombinatorElements(t *testing.T) {
+	// anyOf/oneOf/not elements carrying refs are resolved element by element
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			AnyOf: []spec.Schema{
+				{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/A")}},
+				{SchemaProps: spec.SchemaProps{Required: []string{"name"}}},
+			},
+			OneOf: []spec.Schema{
+				{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/B")}},
+			},
+			Not: &spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("#/definitions/A")}},
+		},
+	}
+	schemas := map[string]*spec.Schema{
+		"#/definitions/Root": root,
+		"#/definitions/A":    {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		"#/definitions/B":    {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "#/definitions/Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resolved.AnyOf[0].Type.Contains("string") {
+		t.Errorf("expected the anyOf ref to be resolved, got %+v", resolved.AnyOf[0])
+	}
+	if len(resolved.AnyOf[1].Required) != 1 {
+		t.Errorf("expected the ref-free anyOf element to be preserved, got %+v", resolved.AnyOf[1])
+	}
+	if !resolved.OneOf[0].Type.Contains("integer") {
+		t.Errorf("expected the oneOf ref to be resolved, got %+v", resolved.OneOf[0])
+	}
+	if !resolved.Not.Type.Contains("string") {
+		t.Errorf("expected the not ref to be resolved, got %+v", resolved.Not)
+	}
+	// the original slices keep their refs
+	if root.AnyOf[0].Ref.GetURL() == nil || root.OneOf[0].Ref.GetURL() == nil || root.Not.Ref.GetURL() == nil {
+		t.Error("original combinator slices were mutated")
+	}
+}
+
+func TestPopulateRefs_AdditionalPropertiesAllowsFalse(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AdditionalProperties: &spec.SchemaOrBool{
+					Allows: false,
+					Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Value")}},
+				},
+			},
+		},
+		"Value": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.AdditionalProperties == nil || resolved.AdditionalProperties.Schema == nil {
+		t.Fatal("expected additionalProperties to survive resolution")
+	}
+	if resolved.AdditionalProperties.Schema.Ref.GetURL() != nil {
+		t.Error("expected the ref under additionalProperties to be resolved despite Allows=false")
+	}
+	if resolved.AdditionalProperties.Allows {
+		t.Error("expected Allows to be preserved verbatim on the copy")
+	}
+	// the original SchemaOrBool must not be mutated
+	original := schemas["Root"].AdditionalProperties
+	if original.Schema.Ref.GetURL() == nil {
+		t.Error("expected the original schema to keep its ref")
+	}
+}
+
+func TestPopulateRefs_TupleItems(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"array"},
+				Items: &spec.SchemaOrArray{Schemas: []spec.Schema{
+					{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					{SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Second")}},
+				}},
+			},
+		},
+		"Second": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.Items == nil || len(resolved.Items.Schemas) != 2 {
+		t.Fatalf("expected a two-element tuple, got %v", resolved.Items)
+	}
+	second := resolved.Items.Schemas[1]
+	if second.Ref.GetURL() != nil {
+		t.Error("expected the tuple element ref to be resolved")
+	}
+	if len(second.Type) != 1 || second.Type[0] != "integer" {
+		t.Errorf("expected the referred schema, got %v", second)
+	}
+	// the original tuple must keep its ref
+	originalSecond := schemas["Root"].Items.Schemas[1]
+	if originalSecond.Ref.GetURL() == nil {
+		t.Error("expected the original schema to keep its ref")
+	}
+}
+
+func TestPopulateRefs_ReferencingAnnotationsWin(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"described": {SchemaProps: spec.SchemaProps{
+						Ref:         spec.MustCreateRef("Target"),
+						Description: "the referencing description",
+						Title:       "Referencing",
+					}},
+					"plain": {SchemaProps: spec.SchemaProps{
+						Ref: spec.MustCreateRef("Target"),
+					}},
+				},
+			},
+		},
+		"Target": {SchemaProps: spec.SchemaProps{
+			Type:        []string{"object"},
+			Description: "the target description",
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	described := resolved.Properties["described"]
+	if described.Description != "the referencing description" {
+		t.Errorf("expected the referencing description to win, got %q", described.Description)
+	}
+	if described.Title != "Referencing" {
+		t.Errorf("expected the referencing title to be kept, got %q", described.Title)
+	}
+	plain := resolved.Properties["plain"]
+	if plain.Description != "the target description" {
+		t.Errorf("expected the target description when the referencing one is empty, got %q", plain.Description)
+	}
+	// the original referencing node is untouched
+	original := schemas["Root"].Properties["described"]
+	if original.Ref.GetURL() == nil || original.Description != "the referencing description" {
+		t.Errorf("original referencing node was mutated: %+v", original)
+	}
+}
+
+func TestPopulateRefs_PatternPropertiesAndDependencies(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			PatternProperties: map[string]spec.Schema{
+				"^x-": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Value")}},
+			},
+			Dependencies: spec.Dependencies{
+				"credit": {Schema: &spec.Schema{
+					SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Value")},
+				}},
+				"billing": {Property: []string{"address"}},
+			},
+		}},
+		"Value": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pattern, ok := resolved.PatternProperties["^x-"]
+	if !ok {
+		t.Fatal("expected the regex key to be kept verbatim")
+	}
+	if pattern.Ref.GetURL() != nil || pattern.Type[0] != "string" {
+		t.Errorf("expected the pattern property ref to resolve, got %v", pattern)
+	}
+	credit := resolved.Dependencies["credit"]
+	if credit.Schema == nil || credit.Schema.Ref.GetURL() != nil {
+		t.Errorf("expected the schema dependency ref to resolve, got %v", credit)
+	}
+	if billing := resolved.Dependencies["billing"]; len(billing.Property) != 1 {
+		t.Errorf("expected the property-list dependency to pass through, got %v", billing)
+	}
+	// originals are untouched
+	originalPattern := schemas["Root"].PatternProperties["^x-"]
+	if originalPattern.Ref.GetURL() == nil {
+		t.Error("original pattern property ref was mutated")
+	}
+	if schemas["Root"].Dependencies["credit"].Schema.Ref.GetURL() == nil {
+		t.Error("original dependency ref was mutated")
+	}
+}
+
+func TestPopulateRefsWithErrors(t *testing.T) {
+	lookupFailure := errors.New("connection refused")
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+			},
+		}},
+		"Spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"template": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Template")}},
+			},
+		}},
+		"Template": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+
+	// a transport failure partway through the graph aborts with that error
+	schemaOf := func(ref string) (*spec.Schema, bool, error) {
+		if ref == "Template" {
+			return nil, false, lookupFailure
+		}
+		s, ok := schemas[ref]
+		return s, ok, nil
+	}
+	_, err := PopulateRefsWithErrors(schemaOf, "Root", Options{})
+	if !errors.Is(err, lookupFailure) {
+		t.Errorf("expected the lookup failure to propagate, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "Template") {
+		t.Errorf("expected the error to name the ref, got %v", err)
+	}
+
+	// a plain not-found still reports an UnresolvedRefError
+	schemaOf = func(ref string) (*spec.Schema, bool, error) {
+		if ref == "Template" {
+			return nil, false, nil
+		}
+		s, ok := schemas[ref]
+		return s, ok, nil
+	}
+	var unresolvedErr *UnresolvedRefError
+	_, err = PopulateRefsWithErrors(schemaOf, "Root", Options{})
+	if !errors.As(err, &unresolvedErr) {
+		t.Fatalf("expected an UnresolvedRefError for a missing ref, got %v", err)
+	}
+	if unresolvedErr.Ref != "Template" {
+		t.Errorf("expected the missing ref in the error, got %q", unresolvedErr.Ref)
+	}
+}
+
+func TestPopulateRefs_NoOpOnResolvedSchema(t *testing.T) {
+	// a fully resolved schema with nested objects, arrays, maps, and
+	// combinators comes back as the same pointer, not a fresh copy.
+	resolved := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"containers": {SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							Properties: map[string]spec.Schema{
+								"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+							},
+						}}},
+					}},
+					"labels": {SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+						}},
+					}},
+				},
+			}},
+		},
+		AllOf: []spec.Schema{
+			{SchemaProps: spec.SchemaProps{Required: []string{"spec"}}},
+		},
+	}}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		if ref == "Root" {
+			return resolved, true
+		}
+		return nil, false
+	}
+	got, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != resolved {
+		t.Error("expected re-resolving an already-resolved schema to return the same pointer")
+	}
+}
+
+func TestPopulateRefs_BooleanSchemaTargets(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"closed": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Nothing")},
+					}},
+				}},
+				"open": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Anything")},
+					}},
+				}},
+			},
+		}},
+		// the canonical boolean "false" schema
+		"Nothing": {SchemaProps: spec.SchemaProps{Not: &spec.Schema{}}},
+		// the boolean "true" schema carries no constraints at all
+		"Anything": {},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefs(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	closed := resolved.Properties["closed"].AdditionalProperties
+	if closed == nil || closed.Allows || closed.Schema != nil {
+		t.Errorf("expected additionalProperties to fold to Allows=false with no schema, got %+v", closed)
+	}
+	open := resolved.Properties["open"].AdditionalProperties
+	if open == nil || !open.Allows || open.Schema != nil {
+		t.Errorf("expected additionalProperties to fold to Allows=true with no schema, got %+v", open)
+	}
+	// the original is untouched
+	original := schemas["Root"].Properties["closed"].AdditionalProperties
+	if original.Schema == nil || original.Schema.Ref.GetURL() == nil {
+		t.Error("original additionalProperties ref was mutated")
+	}
+}
+
+func TestPopulateRefsInSchema(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+	// an in-memory root that was never registered under a ref
+	root := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+		},
+	}}
+
+	resolved, err := PopulateRefsInSchema(schemaOf, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved == root {
+		t.Fatal("expected a copy when refs were expanded")
+	}
+	if _, ok := resolved.Properties["spec"].Properties["replicas"]; !ok {
+		t.Errorf("expected the ref to be expanded, got %v", resolved.Properties["spec"])
+	}
+	// the provided root is never mutated
+	originalSpec := root.Properties["spec"]
+	if originalSpec.Ref.GetURL() == nil {
+		t.Error("the provided root was mutated")
+	}
+
+	// a ref-free root comes back as the same pointer
+	if again, err := PopulateRefsInSchema(schemaOf, resolved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if again != resolved {
+		t.Error("expected a ref-free root to be returned unchanged")
+	}
+}
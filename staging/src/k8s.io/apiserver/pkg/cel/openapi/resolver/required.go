@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// RequiredDepth computes the longest chain of required fields starting at the
+// root of a resolved schema: a schema whose required field itself requires a
+// field, and so on. It tells CEL authors the minimal object depth that must
+// always exist on any valid instance. A schema with no required properties
+// has depth 0.
+func RequiredDepth(s *spec.Schema) int {
+	depth := 0
+	for _, name := range s.Required {
+		prop, ok := s.Properties[name]
+		if !ok {
+			continue
+		}
+		if d := 1 + RequiredDepth(&prop); d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestRequiredDepth(t *testing.T) {
+	three := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{"spec"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type:     []string{"object"},
+					Required: []string{"selector"},
+					Properties: map[string]spec.Schema{
+						"selector": {SchemaProps: spec.SchemaProps{
+							Type:     []string{"object"},
+							Required: []string{"matchLabels"},
+							Properties: map[string]spec.Schema{
+								"matchLabels": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+							},
+						}},
+						// an optional branch does not extend the chain
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					},
+				}},
+				"status": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+			},
+		},
+	}
+	if got := RequiredDepth(three); got != 3 {
+		t.Errorf("expected depth 3, got %d", got)
+	}
+
+	flat := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		},
+	}}
+	if got := RequiredDepth(flat); got != 0 {
+		t.Errorf("expected depth 0 without required fields, got %d", got)
+	}
+
+	// a required name without a matching property does not count
+	dangling := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:     []string{"object"},
+		Required: []string{"missing"},
+	}}
+	if got := RequiredDepth(dangling); got != 0 {
+		t.Errorf("expected depth 0 for a dangling required name, got %d", got)
+	}
+}
@@ -34,6 +34,15 @@ type SchemaResolver interface {
 	ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error)
 }
 
+// ListableResolver is implemented by resolvers that can enumerate the
+// GroupVersionKinds they are able to resolve, e.g. for pre-warming caches or
+// validating coverage. Resolvers that discover types lazily need not
+// implement it.
+type ListableResolver interface {
+	// ListKinds returns every GVK the resolver can resolve.
+	ListKinds() ([]schema.GroupVersionKind, error)
+}
+
 // ErrSchemaNotFound is wrapped and returned if the schema cannot be located
 // by the resolver.
 var ErrSchemaNotFound = fmt.Errorf("schema not found")
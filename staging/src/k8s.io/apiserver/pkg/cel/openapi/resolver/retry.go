@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// RetryingResolver wraps another SchemaResolver and retries transient
+// resolution failures with the configured backoff. Permanent failures, in
+// particular not-found, are returned immediately.
+type RetryingResolver struct {
+	delegate SchemaResolver
+	backoff  wait.Backoff
+	// isTransient classifies errors; only errors it reports as transient are
+	// retried.
+	isTransient func(error) bool
+}
+
+var _ SchemaResolver = (*RetryingResolver)(nil)
+
+// NewRetryingResolver returns a RetryingResolver that retries ResolveSchema
+// calls on the delegate with the given backoff.
+// isTransient classifies which errors are worth retrying; if nil, every error
+// except not-found is considered transient. Errors wrapping ErrSchemaNotFound
+// are never retried.
+func NewRetryingResolver(delegate SchemaResolver, backoff wait.Backoff, isTransient func(error) bool) *RetryingResolver {
+	return &RetryingResolver{delegate: delegate, backoff: backoff, isTransient: isTransient}
+}
+
+// ResolveSchema resolves the schema via the delegate, retrying transient
+// failures. It returns the last error observed if the backoff is exhausted.
+func (r *RetryingResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	var result *spec.Schema
+	var lastErr error
+	err := wait.ExponentialBackoff(r.backoff, func() (bool, error) {
+		s, resolveErr := r.delegate.ResolveSchema(gvk)
+		if resolveErr == nil {
+			result = s
+			return true, nil
+		}
+		if errors.Is(resolveErr, ErrSchemaNotFound) {
+			return false, resolveErr
+		}
+		if r.isTransient != nil && !r.isTransient(resolveErr) {
+			return false, resolveErr
+		}
+		lastErr = resolveErr
+		return false, nil
+	})
+	if err != nil {
+		if lastErr != nil && wait.Interrupted(err) {
+			return nil, lastErr
+		}
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// flakyResolver fails the first failures calls with err, then succeeds.
+type flakyResolver struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakyResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}, nil
+}
+
+func testBackoff() wait.Backoff {
+	return wait.Backoff{Duration: time.Millisecond, Factor: 1.0, Steps: 5}
+}
+
+func TestRetryingResolverRetriesTransientErrors(t *testing.T) {
+	delegate := &flakyResolver{failures: 2, err: fmt.Errorf("connection refused")}
+	r := NewRetryingResolver(delegate, testBackoff(), nil)
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	if _, err := r.ResolveSchema(gvk); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delegate.calls != 3 {
+		t.Errorf("expected 3 calls (2 failures then success), got %d", delegate.calls)
+	}
+}
+
+func TestRetryingResolverDoesNotRetryNotFound(t *testing.T) {
+	delegate := &flakyResolver{failures: 2, err: fmt.Errorf("cannot resolve: %w", ErrSchemaNotFound)}
+	r := NewRetryingResolver(delegate, testBackoff(), nil)
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	if _, err := r.ResolveSchema(gvk); !errors.Is(err, ErrSchemaNotFound) {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+	if delegate.calls != 1 {
+		t.Errorf("expected a single call for not-found, got %d", delegate.calls)
+	}
+}
+
+func TestRetryingResolverHonorsClassifier(t *testing.T) {
+	permanent := fmt.Errorf("invalid schema document")
+	delegate := &flakyResolver{failures: 2, err: permanent}
+	r := NewRetryingResolver(delegate, testBackoff(), func(err error) bool { return false })
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	if _, err := r.ResolveSchema(gvk); !errors.Is(err, permanent) {
+		t.Fatalf("expected the permanent error, got %v", err)
+	}
+	if delegate.calls != 1 {
+		t.Errorf("expected a single call for a permanent error, got %d", delegate.calls)
+	}
+}
+
+func TestRetryingResolverExhaustsBackoff(t *testing.T) {
+	transient := fmt.Errorf("connection refused")
+	delegate := &flakyResolver{failures: 100, err: transient}
+	r := NewRetryingResolver(delegate, testBackoff(), nil)
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	if _, err := r.ResolveSchema(gvk); !errors.Is(err, transient) {
+		t.Fatalf("expected the last transient error after exhausting retries, got %v", err)
+	}
+}
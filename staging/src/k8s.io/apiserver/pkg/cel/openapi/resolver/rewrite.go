@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// RewriteRule declares a rewrite applied to resolved schema nodes whose field
+// path matches PathPattern.
+type RewriteRule struct {
+	// PathPattern is a dotted field path where "*" matches any single
+	// segment, e.g. "spec.secrets.*". Array item nodes carry a "[]" suffix on
+	// their parent segment, as in "spec.containers[]".
+	PathPattern string
+	// Rewrite mutates a deep copy of the matched node. The delegate's
+	// schemas are never modified.
+	Rewrite func(s *spec.Schema)
+}
+
+// rewriteRuleResolver applies a declarative rule set to schemas after
+// resolution by the delegate.
+type rewriteRuleResolver struct {
+	delegate SchemaResolver
+	rules    []RewriteRule
+}
+
+var _ SchemaResolver = (*rewriteRuleResolver)(nil)
+
+// NewRewriteRuleResolver returns a SchemaResolver that resolves via the
+// delegate and then applies the given rewrite rules. Matched nodes are deep
+// copied before rewriting, so schemas cached by the delegate stay untouched.
+func NewRewriteRuleResolver(delegate SchemaResolver, rules []RewriteRule) SchemaResolver {
+	return &rewriteRuleResolver{delegate: delegate, rules: rules}
+}
+
+func (r *rewriteRuleResolver) ResolveSchema(gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	s, err := r.delegate.ResolveSchema(gvk)
+	if err != nil {
+		return nil, err
+	}
+	rewritten, _, err := r.apply(s, "")
+	if err != nil {
+		return nil, err
+	}
+	return rewritten, nil
+}
+
+// apply rewrites the node at path and its children, copying only what
+// changes.
+func (r *rewriteRuleResolver) apply(s *spec.Schema, path string) (*spec.Schema, bool, error) {
+	result := *s
+	changed := false
+	for _, rule := range r.rules {
+		if rule.Rewrite == nil || !matchPathPattern(rule.PathPattern, path) {
+			continue
+		}
+		if !changed {
+			copied, err := deepCopySchema(s)
+			if err != nil {
+				return nil, false, err
+			}
+			result = *copied
+			changed = true
+		}
+		rule.Rewrite(&result)
+	}
+	props := make(map[string]spec.Schema, len(result.Properties))
+	propsChanged := false
+	for name, prop := range result.Properties {
+		rewritten, childChanged, err := r.apply(&prop, childPath(path, name))
+		if err != nil {
+			return nil, false, err
+		}
+		if childChanged {
+			propsChanged = true
+		}
+		props[name] = *rewritten
+	}
+	if propsChanged {
+		changed = true
+		result.Properties = props
+	}
+	if result.Items != nil && result.Items.Schema != nil {
+		rewritten, childChanged, err := r.apply(result.Items.Schema, path+"[]")
+		if err != nil {
+			return nil, false, err
+		}
+		if childChanged {
+			changed = true
+			newItems := *result.Items
+			newItems.Schema = rewritten
+			result.Items = &newItems
+		}
+	}
+	if result.AdditionalProperties != nil && result.AdditionalProperties.Schema != nil {
+		rewritten, childChanged, err := r.apply(result.AdditionalProperties.Schema, path+"[]")
+		if err != nil {
+			return nil, false, err
+		}
+		if childChanged {
+			changed = true
+			newProps := *result.AdditionalProperties
+			newProps.Schema = rewritten
+			result.AdditionalProperties = &newProps
+		}
+	}
+	if changed {
+		return &result, true, nil
+	}
+	return s, false, nil
+}
+
+// matchPathPattern reports whether a dotted path matches a pattern where "*"
+// matches any single segment.
+func matchPathPattern(pattern, path string) bool {
+	patternSegments := strings.Split(pattern, ".")
+	pathSegments := strings.Split(path, ".")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+	for i := range patternSegments {
+		if patternSegments[i] != "*" && patternSegments[i] != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// deepCopySchema copies a schema via its JSON serialization.
+func deepCopySchema(s *spec.Schema) (*spec.Schema, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	copied := new(spec.Schema)
+	if err := json.Unmarshal(b, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
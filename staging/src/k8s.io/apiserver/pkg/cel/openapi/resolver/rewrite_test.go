@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestRewriteRuleResolver(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	original := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"secrets": {SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							Properties: map[string]spec.Schema{
+								"token":    {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+								"password": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+							},
+						}},
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					},
+				}},
+			},
+		},
+	}
+	r := NewRewriteRuleResolver(&fakeResolver{schema: original}, []RewriteRule{
+		{
+			PathPattern: "spec.secrets.*",
+			Rewrite: func(s *spec.Schema) {
+				s.ReadOnly = true
+				s.Description = "internal"
+			},
+		},
+	})
+
+	result, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"token", "password"} {
+		rewritten := result.Properties["spec"].Properties["secrets"].Properties[name]
+		if !rewritten.ReadOnly || rewritten.Description != "internal" {
+			t.Errorf("expected %s to be rewritten, got %+v", name, rewritten.SchemaProps)
+		}
+	}
+	// non-matching nodes are unchanged
+	if replicas := result.Properties["spec"].Properties["replicas"]; replicas.ReadOnly {
+		t.Error("non-matching node was rewritten")
+	}
+	// the delegate's schema is untouched
+	token := original.Properties["spec"].Properties["secrets"].Properties["token"]
+	if token.ReadOnly || token.Description != "" {
+		t.Error("delegate schema was mutated by the rewrite")
+	}
+}
+
+func TestRewriteRuleResolverNoMatch(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	original := &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	r := NewRewriteRuleResolver(&fakeResolver{schema: original}, []RewriteRule{
+		{PathPattern: "spec.missing", Rewrite: func(s *spec.Schema) { s.ReadOnly = true }},
+	})
+	result, err := r.ResolveSchema(gvk)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != original {
+		t.Error("expected the original schema back when no rule matches")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// scalarIntOrString is the Options.ScalarRefs value selecting an
+// x-kubernetes-int-or-string leaf.
+const scalarIntOrString = "int-or-string"
+
+// DefaultScalarRefs returns the standard Kubernetes definitions that
+// serialize as scalars, mapped to the leaves they should resolve to, for use
+// as Options.ScalarRefs.
+func DefaultScalarRefs() map[string]string {
+	return map[string]string{
+		"io.k8s.apimachinery.pkg.apis.meta.v1.Time":       "string:date-time",
+		"io.k8s.apimachinery.pkg.apis.meta.v1.MicroTime":  "string:date-time",
+		"io.k8s.apimachinery.pkg.apis.meta.v1.Duration":   "string",
+		"io.k8s.apimachinery.pkg.api.resource.Quantity":   "string",
+		"io.k8s.apimachinery.pkg.util.intstr.IntOrString": scalarIntOrString,
+	}
+}
+
+// scalarLeafSchema builds the leaf schema for an Options.ScalarRefs value.
+func scalarLeafSchema(scalar string) *spec.Schema {
+	if scalar == scalarIntOrString {
+		return &spec.Schema{
+			VendorExtensible: spec.VendorExtensible{Extensions: map[string]interface{}{
+				extIntOrString: true,
+			}},
+		}
+	}
+	leaf := &spec.Schema{}
+	if typ, format, found := strings.Cut(scalar, ":"); found {
+		leaf.Type = []string{typ}
+		leaf.Format = format
+	} else {
+		leaf.Type = []string{scalar}
+	}
+	return leaf
+}
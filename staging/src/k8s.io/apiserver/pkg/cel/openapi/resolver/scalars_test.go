@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestScalarRefs(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"creationTimestamp": {SchemaProps: spec.SchemaProps{
+					Ref: spec.MustCreateRef("io.k8s.apimachinery.pkg.apis.meta.v1.Time"),
+				}},
+				"port": {SchemaProps: spec.SchemaProps{
+					Ref: spec.MustCreateRef("io.k8s.apimachinery.pkg.util.intstr.IntOrString"),
+				}},
+			},
+		}},
+		// the full object forms that must not be expanded
+		"io.k8s.apimachinery.pkg.apis.meta.v1.Time": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"seconds": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+			},
+		}},
+		"io.k8s.apimachinery.pkg.util.intstr.IntOrString": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefsWithOptions(schemaOf, "Root", Options{ScalarRefs: DefaultScalarRefs()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timestamp := resolved.Properties["creationTimestamp"]
+	if len(timestamp.Type) != 1 || timestamp.Type[0] != "string" || timestamp.Format != "date-time" {
+		t.Errorf("expected Time to resolve to a date-time string leaf, got %v", timestamp)
+	}
+	if len(timestamp.Properties) != 0 {
+		t.Error("expected the Time object form not to be expanded")
+	}
+	port := resolved.Properties["port"]
+	if isIntOrString, ok := port.Extensions.GetBool(extIntOrString); !ok || !isIntOrString {
+		t.Errorf("expected IntOrString to resolve to an int-or-string leaf, got %v", port)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// PopulateRefsShallow resolves only a single level of refs: the root's own
+// ref and the refs in the root's immediate child positions, which are its
+// property values, its items schema, and its additionalProperties schema.
+// Refs inside the substituted schemas are left intact, so a grandchild ref
+// stays unresolved. This pairs with consumers that expand on demand as they
+// descend, instead of paying for the full expansion up front.
+//
+// The original schemas are not mutated; substitutions happen on copies.
+func PopulateRefsShallow(schemaOf func(ref string) (*spec.Schema, bool), rootRef string) (*spec.Schema, error) {
+	rootSchema, ok := schemaOf(rootRef)
+	if !ok {
+		return nil, fmt.Errorf("internal error: cannot resolve Ref for root schema %q: %w", rootRef, ErrRootNotFound)
+	}
+	result := *rootSchema
+	// the root definition may itself be an alias carrying only a ref
+	if resolved, err := resolveOneLevel(schemaOf, &result, ""); err != nil {
+		return nil, err
+	} else {
+		result = *resolved
+	}
+	if len(result.Properties) > 0 {
+		props := make(map[string]spec.Schema, len(result.Properties))
+		for name, prop := range result.Properties {
+			resolved, err := resolveOneLevel(schemaOf, &prop, name)
+			if err != nil {
+				return nil, err
+			}
+			props[name] = *resolved
+		}
+		result.Properties = props
+	}
+	if result.Items != nil && result.Items.Schema != nil {
+		resolved, err := resolveOneLevel(schemaOf, result.Items.Schema, "[*]")
+		if err != nil {
+			return nil, err
+		}
+		newItems := *result.Items
+		newItems.Schema = resolved
+		result.Items = &newItems
+	}
+	if result.AdditionalProperties != nil && result.AdditionalProperties.Schema != nil {
+		resolved, err := resolveOneLevel(schemaOf, result.AdditionalProperties.Schema, "[*]")
+		if err != nil {
+			return nil, err
+		}
+		newProps := *result.AdditionalProperties
+		newProps.Schema = resolved
+		result.AdditionalProperties = &newProps
+	}
+	return &result, nil
+}
+
+// resolveOneLevel substitutes the referred schema for s when s carries a ref
+// (directly or allOf-wrapped), without descending into the substitute. A
+// schema without a ref comes back unchanged.
+func resolveOneLevel(schemaOf func(ref string) (*spec.Schema, bool), s *spec.Schema, path string) (*spec.Schema, error) {
+	ref, isRef := refOf(s)
+	if !isRef {
+		return s, nil
+	}
+	resolved, ok := schemaOf(ref)
+	if !ok {
+		return nil, &UnresolvedRefError{Ref: ref, Path: displayPath(path)}
+	}
+	return resolved, nil
+}
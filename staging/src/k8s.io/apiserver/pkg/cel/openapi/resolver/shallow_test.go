@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestPopulateRefsShallow(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+			},
+		}},
+		"Spec": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"template": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Template")}},
+			},
+		}},
+		"Template": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefsShallow(schemaOf, "Root")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the immediate child ref is resolved
+	specProp := resolved.Properties["spec"]
+	if specProp.Ref.GetURL() != nil {
+		t.Error("expected the property-level ref to be resolved")
+	}
+	// the grandchild ref inside the substituted schema is left intact
+	template := specProp.Properties["template"]
+	if template.Ref.GetURL() == nil {
+		t.Error("expected the grandchild ref to stay unresolved")
+	}
+	// the original property map keeps its ref
+	original := schemas["Root"].Properties["spec"]
+	if original.Ref.GetURL() == nil {
+		t.Error("original property ref was mutated")
+	}
+}
+
+func TestPopulateRefsShallowAliasRoot(t *testing.T) {
+	schemas := map[string]*spec.Schema{
+		"Alias": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Target")}},
+		"Target": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			},
+		}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	resolved, err := PopulateRefsShallow(schemaOf, "Alias")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resolved.Properties["name"]; !ok {
+		t.Errorf("expected the alias root to resolve to its target, got %v", resolved)
+	}
+}
+
+func TestPopulateRefsShallowMissingRefs(t *testing.T) {
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		return nil, false
+	}
+	if _, err := PopulateRefsShallow(schemaOf, "Missing"); !errors.Is(err, ErrRootNotFound) {
+		t.Errorf("expected ErrRootNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const (
+	// extPreserveUnknownFields marks a node whose unknown fields are kept.
+	extPreserveUnknownFields = "x-kubernetes-preserve-unknown-fields"
+	// extIntOrString marks a node accepting either an integer or a string.
+	extIntOrString = "x-kubernetes-int-or-string"
+)
+
+// IsStructural reports whether a resolved schema satisfies the structural
+// schema rules Kubernetes requires of CRDs: every specified node declares a
+// type (unless it preserves unknown fields or is an int-or-string), $ref does
+// not appear, properties and additionalProperties are not combined, and
+// logical junctors (allOf, anyOf, oneOf, not) do not set structural keywords
+// such as type, additionalProperties, default, or nullable. It mirrors the
+// apiextensions structural checks but operates on resolved spec.Schema trees.
+// Violations are returned with their paths.
+func IsStructural(s *spec.Schema) (bool, []string) {
+	violations := checkStructural(s, "", false)
+	return len(violations) == 0, violations
+}
+
+func checkStructural(s *spec.Schema, path string, inJunctor bool) []string {
+	var violations []string
+	at := displayPath(path)
+	if s.Ref.GetURL() != nil {
+		violations = append(violations, fmt.Sprintf("%s: $ref is not allowed in a structural schema", at))
+	}
+	if inJunctor {
+		if len(s.Type) > 0 {
+			violations = append(violations, fmt.Sprintf("%s: type is forbidden inside allOf, anyOf, oneOf, or not", at))
+		}
+		if s.AdditionalProperties != nil {
+			violations = append(violations, fmt.Sprintf("%s: additionalProperties is forbidden inside allOf, anyOf, oneOf, or not", at))
+		}
+		if s.Default != nil {
+			violations = append(violations, fmt.Sprintf("%s: default is forbidden inside allOf, anyOf, oneOf, or not", at))
+		}
+		if s.Nullable {
+			violations = append(violations, fmt.Sprintf("%s: nullable is forbidden inside allOf, anyOf, oneOf, or not", at))
+		}
+	} else if len(s.Type) == 0 {
+		preserve, _ := s.Extensions.GetBool(extPreserveUnknownFields)
+		intOrString, _ := s.Extensions.GetBool(extIntOrString)
+		if !preserve && !intOrString {
+			violations = append(violations, fmt.Sprintf("%s: must declare a type", at))
+		}
+	}
+	if len(s.Properties) > 0 && s.AdditionalProperties != nil {
+		violations = append(violations, fmt.Sprintf("%s: properties and additionalProperties must not be used together", at))
+	}
+
+	for _, name := range sets.List(sets.KeySet(s.Properties)) {
+		prop := s.Properties[name]
+		violations = append(violations, checkStructural(&prop, childPath(path, name), inJunctor)...)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		violations = append(violations, checkStructural(s.AdditionalProperties.Schema, path+"[*]", inJunctor)...)
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		violations = append(violations, checkStructural(s.Items.Schema, path+"[*]", inJunctor)...)
+	}
+	for i := range s.AllOf {
+		violations = append(violations, checkStructural(&s.AllOf[i], path, true)...)
+	}
+	for i := range s.AnyOf {
+		violations = append(violations, checkStructural(&s.AnyOf[i], path, true)...)
+	}
+	for i := range s.OneOf {
+		violations = append(violations, checkStructural(&s.OneOf[i], path, true)...)
+	}
+	if s.Not != nil {
+		violations = append(violations, checkStructural(s.Not, path, true)...)
+	}
+	return violations
+}
+
+// ValidateStructural applies the same structural-schema rules as
+// IsStructural but reports violations as a field.ErrorList with
+// JSON-pointer-style paths (e.g. "/properties/spec/items"), so CRD tooling
+// can fail fast with actionable messages. The schema is traversed with
+// WalkSchema.
+func ValidateStructural(s *spec.Schema) field.ErrorList {
+	var errs field.ErrorList
+	// the visitor never fails, so neither does the walk
+	_ = WalkSchema(s, func(path string, node *spec.Schema) error {
+		at := field.NewPath(path)
+		if path == "" {
+			at = field.NewPath("<root>")
+		}
+		if node.Ref.GetURL() != nil {
+			errs = append(errs, field.Forbidden(at.Child("$ref"), "$ref is not allowed in a structural schema"))
+		}
+		if walkPathInJunctor(path) {
+			if len(node.Type) > 0 {
+				errs = append(errs, field.Forbidden(at.Child("type"), "must not be set inside allOf, anyOf, oneOf, or not"))
+			}
+			if node.AdditionalProperties != nil {
+				errs = append(errs, field.Forbidden(at.Child("additionalProperties"), "must not be set inside allOf, anyOf, oneOf, or not"))
+			}
+			if node.Default != nil {
+				errs = append(errs, field.Forbidden(at.Child("default"), "must not be set inside allOf, anyOf, oneOf, or not"))
+			}
+			if node.Nullable {
+				errs = append(errs, field.Forbidden(at.Child("nullable"), "must not be set inside allOf, anyOf, oneOf, or not"))
+			}
+		} else if len(node.Type) == 0 {
+			preserve, _ := node.Extensions.GetBool(extPreserveUnknownFields)
+			intOrString, _ := node.Extensions.GetBool(extIntOrString)
+			if !preserve && !intOrString {
+				errs = append(errs, field.Required(at.Child("type"), "must declare a type"))
+			}
+		}
+		if len(node.Properties) > 0 && node.AdditionalProperties != nil {
+			errs = append(errs, field.Forbidden(at.Child("additionalProperties"), "must not be used together with properties"))
+		}
+		return nil
+	})
+	return errs
+}
+
+// walkPathInJunctor reports whether a WalkSchema path descends through a
+// logical junctor (allOf, anyOf, oneOf, or not). Property names that merely
+// spell a junctor keyword do not count.
+func walkPathInJunctor(path string) bool {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments); i++ {
+		switch segments[i] {
+		case "properties":
+			i++ // the next segment is a property name
+		case "allOf", "anyOf", "oneOf", "not":
+			return true
+		}
+	}
+	return false
+}
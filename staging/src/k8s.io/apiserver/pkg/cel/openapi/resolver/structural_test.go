@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestIsStructural(t *testing.T) {
+	structural := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"replicas": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+						"tags": {SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+								SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+							}},
+						}},
+					},
+					// junctors without structural keywords are allowed
+					AnyOf: []spec.Schema{
+						{SchemaProps: spec.SchemaProps{Required: []string{"replicas"}}},
+						{SchemaProps: spec.SchemaProps{Required: []string{"tags"}}},
+					},
+				}},
+			},
+		},
+	}
+	if ok, violations := IsStructural(structural); !ok {
+		t.Errorf("expected schema to be structural, got violations %v", violations)
+	}
+
+	nonStructural := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				// untyped node
+				"untyped": {SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"inner": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+					},
+				}},
+				// type inside a junctor
+				"composed": {SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					AllOf: []spec.Schema{
+						{SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+					},
+				}},
+			},
+		},
+	}
+	ok, violations := IsStructural(nonStructural)
+	if ok {
+		t.Fatal("expected schema to be non-structural")
+	}
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", violations)
+	}
+	joined := strings.Join(violations, "\n")
+	if !strings.Contains(joined, "untyped: must declare a type") {
+		t.Errorf("expected a violation for the untyped node, got %v", violations)
+	}
+	if !strings.Contains(joined, "composed: type is forbidden") {
+		t.Errorf("expected a violation for type inside allOf, got %v", violations)
+	}
+}
+
+func TestIsStructuralPreserveUnknownFields(t *testing.T) {
+	s := &spec.Schema{
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+			extPreserveUnknownFields: true,
+		}},
+	}
+	if ok, violations := IsStructural(s); !ok {
+		t.Errorf("expected untyped node with preserve-unknown-fields to be allowed, got %v", violations)
+	}
+}
+
+func TestValidateStructural(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		schema    *spec.Schema
+		wantField string
+		wantType  field.ErrorType
+	}{
+		{
+			name: "ref",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"spec": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Spec")}},
+				},
+			}},
+			wantField: "/properties/spec.$ref",
+			wantType:  field.ErrorTypeForbidden,
+		},
+		{
+			name: "untyped",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"untyped": {},
+				},
+			}},
+			wantField: "/properties/untyped.type",
+			wantType:  field.ErrorTypeRequired,
+		},
+		{
+			name: "properties with additionalProperties",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+				AdditionalProperties: &spec.SchemaOrBool{Allows: true},
+			}},
+			wantField: "<root>.additionalProperties",
+			wantType:  field.ErrorTypeForbidden,
+		},
+		{
+			name: "type in junctor",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AnyOf: []spec.Schema{
+					{SchemaProps: spec.SchemaProps{Type: []string{"object"}}},
+				},
+			}},
+			wantField: "/anyOf/0.type",
+			wantType:  field.ErrorTypeForbidden,
+		},
+		{
+			name: "default in junctor",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				AllOf: []spec.Schema{
+					{SchemaProps: spec.SchemaProps{Default: "x"}},
+				},
+			}},
+			wantField: "/allOf/0.default",
+			wantType:  field.ErrorTypeForbidden,
+		},
+		{
+			name: "nullable in junctor",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Not:  &spec.Schema{SchemaProps: spec.SchemaProps{Nullable: true}},
+			}},
+			wantField: "/not.nullable",
+			wantType:  field.ErrorTypeForbidden,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateStructural(tc.schema)
+			for _, err := range errs {
+				if err.Field == tc.wantField && err.Type == tc.wantType {
+					return
+				}
+			}
+			t.Errorf("expected a %s error at %s, got %v", tc.wantType, tc.wantField, errs)
+		})
+	}
+}
+
+func TestValidateStructuralValid(t *testing.T) {
+	s := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+		},
+	}}
+	if errs := ValidateStructural(s); len(errs) != 0 {
+		t.Errorf("expected no errors for a structural schema, got %v", errs)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const (
+	extListType    = "x-kubernetes-list-type"
+	extListMapKeys = "x-kubernetes-list-map-keys"
+	extMapType     = "x-kubernetes-map-type"
+)
+
+// MergeInfo describes the server-side-apply merge semantics of a resolved
+// schema node and its children in a consolidated form, collected from the
+// x-kubernetes-list-type, x-kubernetes-list-map-keys and x-kubernetes-map-type
+// extensions.
+type MergeInfo struct {
+	// ListType is the declared list merge strategy of the node, one of
+	// "atomic", "set" or "map", or empty if undeclared.
+	ListType string
+	// ListMapKeys names the key fields of a list of type "map".
+	ListMapKeys []string
+	// MapType is the declared map merge strategy of the node, one of
+	// "atomic" or "granular", or empty if undeclared.
+	MapType string
+	// Fields holds the merge semantics of the node's properties.
+	Fields map[string]MergeInfo
+	// Items holds the merge semantics of the node's array items, if any.
+	Items *MergeInfo
+	// Values holds the merge semantics of the node's additionalProperties
+	// values, if any.
+	Values *MergeInfo
+}
+
+// MergeTopology walks a resolved schema and returns the tree of
+// server-side-apply merge semantics per path. The schema is expected to be
+// ref-free; refs are not followed.
+func MergeTopology(s *spec.Schema) MergeInfo {
+	info := MergeInfo{}
+	info.ListType, _ = s.Extensions.GetString(extListType)
+	info.MapType, _ = s.Extensions.GetString(extMapType)
+	if keys, ok := s.Extensions.GetStringSlice(extListMapKeys); ok {
+		info.ListMapKeys = keys
+	}
+	if len(s.Properties) > 0 {
+		info.Fields = make(map[string]MergeInfo, len(s.Properties))
+		for name, prop := range s.Properties {
+			info.Fields[name] = MergeTopology(&prop)
+		}
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		items := MergeTopology(s.Items.Schema)
+		info.Items = &items
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		values := MergeTopology(s.AdditionalProperties.Schema)
+		info.Values = &values
+	}
+	return info
+}
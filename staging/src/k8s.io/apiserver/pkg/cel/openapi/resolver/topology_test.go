@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestMergeTopology(t *testing.T) {
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"containers": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+						}},
+					},
+					VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+						extListType:    "map",
+						extListMapKeys: []interface{}{"name"},
+					}},
+				},
+				"tolerations": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"array"},
+						Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+						}},
+					},
+					VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+						extListType: "atomic",
+					}},
+				},
+				"labels": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+						}},
+					},
+					VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+						extMapType: "atomic",
+					}},
+				},
+			},
+		},
+	}
+
+	info := MergeTopology(root)
+	containers := info.Fields["containers"]
+	if containers.ListType != "map" {
+		t.Errorf("expected containers to be a list-map, got %q", containers.ListType)
+	}
+	if !reflect.DeepEqual(containers.ListMapKeys, []string{"name"}) {
+		t.Errorf("expected containers list-map keys [name], got %v", containers.ListMapKeys)
+	}
+	if containers.Items == nil {
+		t.Error("expected containers to carry item merge info")
+	}
+	tolerations := info.Fields["tolerations"]
+	if tolerations.ListType != "atomic" {
+		t.Errorf("expected tolerations to be atomic, got %q", tolerations.ListType)
+	}
+	labels := info.Fields["labels"]
+	if labels.MapType != "atomic" {
+		t.Errorf("expected labels map-type atomic, got %q", labels.MapType)
+	}
+	if labels.Values == nil {
+		t.Error("expected labels to carry value merge info")
+	}
+	if info.ListType != "" || info.MapType != "" {
+		t.Errorf("expected no merge declarations at the root, got %q/%q", info.ListType, info.MapType)
+	}
+}
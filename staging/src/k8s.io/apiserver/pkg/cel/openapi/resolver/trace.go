@@ -0,0 +1,34 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+// ResolutionTrace is a structured report of a single expansion, accumulated
+// when Options.Trace is set. Unlike the ResolutionMetrics hook, which feeds
+// aggregated instrumentation, the trace breaks one slow call down for
+// logging at high verbosity: which refs were followed and how often, how
+// deep the ref nesting went, and where the nodes were spent.
+type ResolutionTrace struct {
+	// RefVisits counts how many times each ref was expanded.
+	RefVisits map[string]int
+	// RefNodes counts the schema nodes visited while expanding each ref's
+	// subtree; nodes under a nested ref count toward the innermost ref.
+	RefNodes map[string]int
+	// MaxDepth is the deepest ref nesting reached.
+	MaxDepth int
+	// UniqueRefs is the number of distinct refs expanded.
+	UniqueRefs int
+}
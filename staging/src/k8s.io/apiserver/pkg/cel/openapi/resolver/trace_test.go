@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestResolutionTraceDiamond(t *testing.T) {
+	// a diamond: Root -> Left -> Leaf and Root -> Right -> Leaf.
+	schemas := map[string]*spec.Schema{
+		"Root": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"left":  {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Left")}},
+				"right": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Right")}},
+			},
+		}},
+		"Left": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"leaf": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Leaf")}},
+			},
+		}},
+		"Right": {SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"leaf": {SchemaProps: spec.SchemaProps{Ref: spec.MustCreateRef("Leaf")}},
+			},
+		}},
+		"Leaf": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+	}
+	schemaOf := func(ref string) (*spec.Schema, bool) {
+		s, ok := schemas[ref]
+		return s, ok
+	}
+
+	trace := &ResolutionTrace{}
+	if _, err := PopulateRefsWithOptions(schemaOf, "Root", Options{Trace: trace}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantVisits := map[string]int{"Root": 1, "Left": 1, "Right": 1, "Leaf": 2}
+	for ref, want := range wantVisits {
+		if got := trace.RefVisits[ref]; got != want {
+			t.Errorf("expected %d visits of %q, got %d", want, ref, got)
+		}
+	}
+	if trace.UniqueRefs != 4 {
+		t.Errorf("expected 4 unique refs, got %d", trace.UniqueRefs)
+	}
+	// Root -> Left/Right -> Leaf nests two refs deep.
+	if trace.MaxDepth != 2 {
+		t.Errorf("expected a max depth of 2, got %d", trace.MaxDepth)
+	}
+	// the leaf is a single node per visit.
+	if got := trace.RefNodes["Leaf"]; got != 2 {
+		t.Errorf("expected 2 nodes attributed to Leaf, got %d", got)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// SkipSubtree can be returned from a WalkSchema visitor to skip descending
+// into the current subschema without aborting the walk.
+var SkipSubtree = errors.New("skip this subtree")
+
+// WalkSchema visits every subschema of s — properties, patternProperties,
+// items, additionalProperties, schema-form dependencies, allOf, anyOf, oneOf,
+// and not — in a deterministic order, calling fn with a JSON-pointer-style
+// path ("" for the root, e.g.
+// "/properties/spec/items" below it). A visitor returning SkipSubtree skips
+// descent into the node just visited; any other error aborts the walk and is
+// returned. Schemas held in maps are visited through copies, so mutations
+// through the visited pointer do not reach every node.
+func WalkSchema(s *spec.Schema, fn func(path string, s *spec.Schema) error) error {
+	if s == nil {
+		return nil
+	}
+	return walkSchema(s, "", fn)
+}
+
+func walkSchema(s *spec.Schema, path string, fn func(path string, s *spec.Schema) error) error {
+	switch err := fn(path, s); {
+	case errors.Is(err, SkipSubtree):
+		return nil
+	case err != nil:
+		return err
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := s.Properties[name]
+		if err := walkSchema(&prop, path+"/properties/"+jsonPointerEscape(name), fn); err != nil {
+			return err
+		}
+	}
+	patterns := make([]string, 0, len(s.PatternProperties))
+	for pattern := range s.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		prop := s.PatternProperties[pattern]
+		if err := walkSchema(&prop, path+"/patternProperties/"+jsonPointerEscape(pattern), fn); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if s.Items.Schema != nil {
+			if err := walkSchema(s.Items.Schema, path+"/items", fn); err != nil {
+				return err
+			}
+		}
+		for i := range s.Items.Schemas {
+			if err := walkSchema(&s.Items.Schemas[i], path+"/items/"+strconv.Itoa(i), fn); err != nil {
+				return err
+			}
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if err := walkSchema(s.AdditionalProperties.Schema, path+"/additionalProperties", fn); err != nil {
+			return err
+		}
+	}
+	depNames := make([]string, 0, len(s.Dependencies))
+	for name, dep := range s.Dependencies {
+		if dep.Schema != nil {
+			depNames = append(depNames, name)
+		}
+	}
+	sort.Strings(depNames)
+	for _, name := range depNames {
+		if err := walkSchema(s.Dependencies[name].Schema, path+"/dependencies/"+jsonPointerEscape(name), fn); err != nil {
+			return err
+		}
+	}
+	for _, combinator := range []struct {
+		name    string
+		schemas []spec.Schema
+	}{
+		{"allOf", s.AllOf},
+		{"anyOf", s.AnyOf},
+		{"oneOf", s.OneOf},
+	} {
+		for i := range combinator.schemas {
+			if err := walkSchema(&combinator.schemas[i], path+"/"+combinator.name+"/"+strconv.Itoa(i), fn); err != nil {
+				return err
+			}
+		}
+	}
+	if s.Not != nil {
+		if err := walkSchema(s.Not, path+"/not", fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPointerEscape escapes a property name for use as a JSON pointer
+// reference token, per RFC 6901.
+func jsonPointerEscape(name string) string {
+	name = strings.ReplaceAll(name, "~", "~0")
+	return strings.ReplaceAll(name, "/", "~1")
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func walkTestSchema() *spec.Schema {
+	return &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"zeta": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			"alpha": {SchemaProps: spec.SchemaProps{
+				Type: []string{"array"},
+				Items: &spec.SchemaOrArray{Schema: &spec.Schema{
+					SchemaProps: spec.SchemaProps{Type: []string{"object"},
+						AdditionalProperties: &spec.SchemaOrBool{Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{Type: []string{"string"}},
+						}},
+					},
+				}},
+			}},
+		},
+		AnyOf: []spec.Schema{
+			{SchemaProps: spec.SchemaProps{Required: []string{"alpha"}}},
+			{SchemaProps: spec.SchemaProps{Required: []string{"zeta"}}},
+		},
+		Not: &spec.Schema{SchemaProps: spec.SchemaProps{Required: []string{"beta"}}},
+	}}
+}
+
+func TestWalkSchema(t *testing.T) {
+	var visited []string
+	err := WalkSchema(walkTestSchema(), func(path string, s *spec.Schema) error {
+		visited = append(visited, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"",
+		"/properties/alpha",
+		"/properties/alpha/items",
+		"/properties/alpha/items/additionalProperties",
+		"/properties/zeta",
+		"/anyOf/0",
+		"/anyOf/1",
+		"/not",
+	}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("expected visit order %v, got %v", want, visited)
+	}
+}
+
+func TestWalkSchemaPatternPropertiesAndDependencies(t *testing.T) {
+	root := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		PatternProperties: map[string]spec.Schema{
+			"^b-": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			"^a-": {SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"leaf": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+				},
+			}},
+		},
+		Dependencies: spec.Dependencies{
+			"flag": {Schema: &spec.Schema{
+				SchemaProps: spec.SchemaProps{Required: []string{"mode"}},
+			}},
+			// property-list form carries no subschema and is not visited.
+			"mode": {Property: []string{"flag"}},
+		},
+	}}
+	var visited []string
+	err := WalkSchema(root, func(path string, s *spec.Schema) error {
+		visited = append(visited, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"",
+		"/patternProperties/^a-",
+		"/patternProperties/^a-/properties/leaf",
+		"/patternProperties/^b-",
+		"/dependencies/flag",
+	}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("expected visit order %v, got %v", want, visited)
+	}
+}
+
+func TestWalkSchemaSkipSubtree(t *testing.T) {
+	var visited []string
+	err := WalkSchema(walkTestSchema(), func(path string, s *spec.Schema) error {
+		visited = append(visited, path)
+		if path == "/properties/alpha" {
+			return SkipSubtree
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, path := range visited {
+		if path == "/properties/alpha/items" {
+			t.Errorf("expected descent below alpha to be skipped, visited %v", visited)
+		}
+	}
+}
+
+func TestWalkSchemaAbortsOnError(t *testing.T) {
+	walkErr := errors.New("stop")
+	count := 0
+	err := WalkSchema(walkTestSchema(), func(path string, s *spec.Schema) error {
+		count++
+		return walkErr
+	})
+	if !errors.Is(err, walkErr) {
+		t.Errorf("expected the visitor error to be returned, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected the walk to abort after the first visit, got %d visits", count)
+	}
+}
+
+func TestJSONPointerEscape(t *testing.T) {
+	if got := jsonPointerEscape("a/b~c"); got != "a~1b~0c" {
+		t.Errorf("expected RFC 6901 escaping, got %q", got)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// warmAllParallelism bounds how many schemas WarmAll resolves concurrently.
+const warmAllParallelism = 4
+
+// WarmAll resolves every GVK the resolver knows about, with bounded
+// parallelism, stopping when ctx is done. It is intended as a one-call
+// startup primitive: run it under a deadline to both pre-validate the
+// definitions and surface broken types before serving.
+//
+// The returned error aggregates one entry per GVK that failed to resolve or
+// was abandoned because the context expired; nil means every known GVK
+// resolved successfully.
+func (d *DefinitionsSchemaResolver) WarmAll(ctx context.Context) error {
+	work := make(chan schema.GroupVersionKind)
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	workers := warmAllParallelism
+	if len(d.gvkToRef) < workers {
+		workers = len(d.gvkToRef)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for gvk := range work {
+				var err error
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = ctxErr
+				} else {
+					_, err = d.ResolveSchema(gvk)
+				}
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("warming %v: %w", gvk, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for gvk := range d.gvkToRef {
+		work <- gvk
+	}
+	close(work)
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestWarmAll(t *testing.T) {
+	resolver := testDefinitionsResolver()
+	if err := resolver.WarmAll(context.Background()); err != nil {
+		t.Fatalf("expected all GVKs to warm, got %v", err)
+	}
+}
+
+func TestWarmAllPartialFailure(t *testing.T) {
+	resolver := testDefinitionsResolver()
+	brokenGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Broken"}
+	resolver.gvkToRef[brokenGVK] = "com.example.v1.Missing"
+
+	err := resolver.WarmAll(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for the broken GVK")
+	}
+	if !strings.Contains(err.Error(), brokenGVK.String()) {
+		t.Errorf("expected the error to name %v, got %v", brokenGVK, err)
+	}
+	if strings.Contains(err.Error(), testGVK.String()) {
+		t.Errorf("expected no error for the healthy %v, got %v", testGVK, err)
+	}
+}
+
+func TestWarmAllDeadlineExpired(t *testing.T) {
+	resolver := testDefinitionsResolver()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := resolver.WarmAll(ctx)
+	if err == nil {
+		t.Fatal("expected an error for the expired context")
+	}
+	if !strings.Contains(err.Error(), testGVK.String()) {
+		t.Errorf("expected the error to name the abandoned %v, got %v", testGVK, err)
+	}
+	if !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Errorf("expected the error to carry the context error, got %v", err)
+	}
+}